@@ -0,0 +1,123 @@
+package apierrors
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const ContentType = "application/problem+json"
+
+var statusCodes = map[int]string{
+	http.StatusBadRequest:            "bad_request",
+	http.StatusUnauthorized:          "unauthorized",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusConflict:              "conflict",
+	http.StatusPreconditionFailed:    "precondition_failed",
+	http.StatusRequestEntityTooLarge: "payload_too_large",
+	http.StatusUnsupportedMediaType:  "unsupported_media_type",
+	http.StatusUnprocessableEntity:   "unprocessable_entity",
+	http.StatusTooManyRequests:       "too_many_requests",
+	http.StatusInternalServerError:   "internal_error",
+	http.StatusServiceUnavailable:    "service_unavailable",
+}
+
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Code       string
+	RequestID  string
+	Errors     []FieldError
+	Extensions map[string]interface{}
+}
+
+func New(status int, detail string) *Problem {
+	code, ok := statusCodes[status]
+	if !ok {
+		code = "error"
+	}
+
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+func (p *Problem) WithCode(code string) *Problem {
+	p.Code = code
+	return p
+}
+
+func (p *Problem) With(key string, value interface{}) *Problem {
+	if p.Extensions == nil {
+		p.Extensions = make(map[string]interface{})
+	}
+	p.Extensions[key] = value
+	return p
+}
+
+func (p *Problem) Send(c *gin.Context) {
+	if p.Instance == "" {
+		p.Instance = c.Request.URL.Path
+	}
+	if p.RequestID == "" {
+		p.RequestID = requestID(c)
+	}
+
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
+
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	body := make(map[string]interface{}, len(p.Extensions)+8)
+	for key, value := range p.Extensions {
+		body[key] = value
+	}
+
+	body["type"] = p.Type
+	body["title"] = p.Title
+	body["status"] = p.Status
+	body["code"] = p.Code
+	if p.Detail != "" {
+		body["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		body["instance"] = p.Instance
+	}
+	if p.RequestID != "" {
+		body["requestId"] = p.RequestID
+	}
+	if len(p.Errors) > 0 {
+		body["errors"] = p.Errors
+	}
+	return json.Marshal(body)
+}
+
+func Write(c *gin.Context, status int, detail string) {
+	New(status, detail).Send(c)
+}
+
+func Internal(c *gin.Context, detail string, err error) {
+	utils.LoggerFrom(c.Request.Context()).Error(detail,
+		zap.String("method", c.Request.Method),
+		zap.String("path", c.Request.URL.Path),
+		zap.Error(err))
+	Write(c, http.StatusInternalServerError, detail)
+}
+
+func requestID(c *gin.Context) string {
+	if id := c.GetString("requestId"); id != "" {
+		return id
+	}
+	return c.GetHeader("X-Request-ID")
+}
@@ -0,0 +1,19 @@
+package apierrors
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RetryLater(c *gin.Context, problem *Problem, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	problem.With("retryAfter", seconds).Send(c)
+}
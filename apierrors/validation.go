@@ -0,0 +1,141 @@
+package apierrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func JSONTagName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+func Validation(c *gin.Context, err error) {
+	validation(c, "", err)
+}
+
+func FieldValidation(c *gin.Context, field string, err error) {
+	validation(c, field, err)
+}
+
+func validation(c *gin.Context, field string, err error) {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		Write(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	problem := New(http.StatusBadRequest, "One or more fields are invalid").WithCode("validation_failed")
+	for _, fieldError := range validationErrors {
+		problem.Errors = append(problem.Errors, describeFieldError(field, fieldError))
+	}
+	problem.Send(c)
+}
+
+func InvalidBody(c *gin.Context, err error) {
+	problem := New(http.StatusBadRequest, "The request body is not valid JSON for this resource").WithCode("invalid_body")
+
+	var typeError *json.UnmarshalTypeError
+	var syntaxError *json.SyntaxError
+	var validationErrors validator.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrors):
+		Validation(c, err)
+		return
+	case errors.As(err, &typeError):
+		problem.Errors = []FieldError{{
+			Field:   typeError.Field,
+			Code:    "invalid_type",
+			Message: fmt.Sprintf("must be of type %s", typeError.Type),
+		}}
+	case errors.As(err, &syntaxError):
+		problem.Detail = fmt.Sprintf("Malformed JSON at offset %d", syntaxError.Offset)
+	case errors.Is(err, io.EOF):
+		problem.Detail = "The request body is empty"
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		problem.Detail = "The request body ends before the JSON document is complete"
+	default:
+		problem.Detail = err.Error()
+	}
+	problem.Send(c)
+}
+
+func describeFieldError(prefix string, fieldError validator.FieldError) FieldError {
+	field := fieldError.Namespace()
+	if prefix != "" {
+		field = prefix + field
+	} else if index := strings.Index(field, "."); index >= 0 {
+		field = field[index+1:]
+	}
+
+	tag, param := fieldError.Tag(), fieldError.Param()
+	if choices, ok := equalityChoices(tag); ok {
+		return FieldError{Field: field, Code: "one_of", Message: "must be one of " + strings.Join(choices, ", ")}
+	}
+
+	switch tag {
+	case "required", "required_without", "required_with", "required_if":
+		return FieldError{Field: field, Code: "required", Message: "is required"}
+	case "email":
+		return FieldError{Field: field, Code: "invalid_email", Message: "must be a valid email address"}
+	case "min", "gte":
+		if fieldError.Kind() == reflect.String {
+			return FieldError{Field: field, Code: "too_short", Message: fmt.Sprintf("must be at least %s characters long", param)}
+		}
+		return FieldError{Field: field, Code: "too_small", Message: fmt.Sprintf("must be at least %s", param)}
+	case "max", "lte":
+		if fieldError.Kind() == reflect.String {
+			return FieldError{Field: field, Code: "too_long", Message: fmt.Sprintf("must be at most %s characters long", param)}
+		}
+		return FieldError{Field: field, Code: "too_large", Message: fmt.Sprintf("must be at most %s", param)}
+	case "gt":
+		return FieldError{Field: field, Code: "too_small", Message: fmt.Sprintf("must be greater than %s", param)}
+	case "lt":
+		return FieldError{Field: field, Code: "too_large", Message: fmt.Sprintf("must be less than %s", param)}
+	case "eq":
+		return FieldError{Field: field, Code: "one_of", Message: "must be " + param}
+	case "oneof":
+		return FieldError{Field: field, Code: "one_of", Message: "must be one of " + strings.Join(strings.Fields(param), ", ")}
+	case "allergen":
+		return FieldError{Field: field, Code: "unknown_allergen", Message: "must be a known allergen"}
+	case "dietaryflag":
+		return FieldError{Field: field, Code: "unknown_dietary_flag", Message: "must be a known dietary flag"}
+	}
+	return FieldError{Field: field, Code: "invalid", Message: fmt.Sprintf("failed the %s check", tag)}
+}
+
+func equalityChoices(tag string) ([]string, bool) {
+	if !strings.Contains(tag, "|") {
+		return nil, false
+	}
+
+	var choices []string
+	for _, part := range strings.Split(tag, "|") {
+		value, ok := strings.CutPrefix(part, "eq=")
+		if !ok {
+			return nil, false
+		}
+		choices = append(choices, value)
+	}
+	return choices, true
+}
@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const redacted = "[REDACTED]"
+
+var ignoredFields = map[string]bool{
+	"_id":       true,
+	"updatedAt": true,
+}
+
+var secretFields = map[string]bool{
+	"password":     true,
+	"accessToken":  true,
+	"refreshToken": true,
+
+	"accessTokenHash":  true,
+	"refreshTokenHash": true,
+}
+
+func ToDocument(value interface{}) (bson.M, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, nil
+	}
+
+	data, err := bson.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var document bson.M
+	if err := bson.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+func Diff(before, after bson.M) []models.AuditChange {
+	fields := make(map[string]bool, len(before)+len(after))
+	for field := range before {
+		fields[field] = true
+	}
+	for field := range after {
+		fields[field] = true
+	}
+
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		if !ignoredFields[field] {
+			names = append(names, field)
+		}
+	}
+	sort.Strings(names)
+
+	changes := []models.AuditChange{}
+	for _, field := range names {
+		oldValue, newValue := before[field], after[field]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		if secretFields[field] {
+			oldValue, newValue = redact(oldValue), redact(newValue)
+		}
+		changes = append(changes, models.AuditChange{Field: field, Before: oldValue, After: newValue})
+	}
+	return changes
+}
+
+func Action(before, after bson.M) string {
+	switch {
+	case before == nil:
+		return models.AuditActionCreate
+	case before["deletedAt"] == nil && after["deletedAt"] != nil:
+		return models.AuditActionDelete
+	case before["deletedAt"] != nil && after["deletedAt"] == nil:
+		return models.AuditActionRestore
+	default:
+		return models.AuditActionUpdate
+	}
+}
+
+func redact(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	return redacted
+}
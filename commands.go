@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/config"
+	"github.com/datarohit/go-restaurant-management-backend-project/database"
+	"github.com/datarohit/go-restaurant-management-backend-project/migrations"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/datarohit/go-restaurant-management-backend-project/seed"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func runCommand(args []string, db *mongo.Database, cfg *config.Config) error {
+	switch args[0] {
+	case "migrate":
+		subcommand := "up"
+		if len(args) > 1 {
+			subcommand = args[1]
+		}
+		switch subcommand {
+		case "up":
+			return migrate(db, cfg)
+		case "status":
+			return migrationStatus(db, cfg)
+		default:
+			return fmt.Errorf("unknown migrate subcommand %q (expected up or status)", subcommand)
+		}
+	case "seed":
+		if cfg.Server.Mode == gin.ReleaseMode {
+			return errors.New("seeding is disabled in release mode; set GIN_MODE=debug to load demo data")
+		}
+		if len(args) > 1 && args[1] == "generate" {
+			return generateDemoData(db, cfg, args[2:])
+		}
+		return seedFixtures(db, cfg, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q (expected migrate or seed)", args[0])
+	}
+}
+
+func migrate(db *mongo.Database, cfg *config.Config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Migrations.Timeout)
+	defer cancel()
+
+	if err := database.WaitUntilReady(ctx); err != nil {
+		return fmt.Errorf("waiting for database: %w", err)
+	}
+
+	_, err := migrations.Run(ctx, db)
+	return err
+}
+
+func migrationStatus(db *mongo.Database, cfg *config.Config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Migrations.Timeout)
+	defer cancel()
+
+	if err := database.WaitUntilReady(ctx); err != nil {
+		return fmt.Errorf("waiting for database: %w", err)
+	}
+
+	statuses, err := migrations.Status(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(statuses)
+}
+
+func seedFixtures(db *mongo.Database, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	file := flags.String("file", cfg.Seed.FixturesFile, "fixture file (.yaml, .yml or .json)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	fixtures, err := seed.LoadFixtures(*file)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Seed.Timeout)
+	defer cancel()
+
+	if err := database.WaitUntilReady(ctx); err != nil {
+		return fmt.Errorf("waiting for database: %w", err)
+	}
+
+	summary, err := seed.NewSeeder(repositories.NewMongoRepositories(db)).Seed(ctx, fixtures)
+	if err != nil {
+		return err
+	}
+	return printJSON(summary)
+}
+
+func generateDemoData(db *mongo.Database, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("seed generate", flag.ContinueOnError)
+	months := flags.Int("months", 3, "number of months of history to generate")
+	ordersPerDay := flags.Int("orders-per-day", 40, "average number of orders per day")
+	maxItems := flags.Int("max-items", 5, "maximum number of items per order")
+	randomSeed := flags.Int64("seed", time.Now().UnixNano(), "random seed for reproducible data")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Seed.Timeout)
+	defer cancel()
+
+	if err := database.WaitUntilReady(ctx); err != nil {
+		return fmt.Errorf("waiting for database: %w", err)
+	}
+
+	summary, err := seed.NewGenerator(repositories.NewMongoRepositories(db)).Generate(ctx, seed.GenerateOptions{
+		Months:           *months,
+		OrdersPerDay:     *ordersPerDay,
+		MaxItemsPerOrder: *maxItems,
+		Seed:             *randomSeed,
+	})
+	if err != nil {
+		return err
+	}
+	return printJSON(summary)
+}
+
+func printJSON(value interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
@@ -0,0 +1,212 @@
+package config
+
+import "time"
+
+const DevelopmentJWTSecret = "not-so-secret"
+
+type Config struct {
+	Server     ServerConfig     `yaml:"server"`
+	Auth       AuthConfig       `yaml:"auth"`
+	Mongo      MongoConfig      `yaml:"mongo"`
+	Migrations MigrationsConfig `yaml:"migrations"`
+	Seed       SeedConfig       `yaml:"seed"`
+	Storage    StorageConfig    `yaml:"storage"`
+	Logging    LoggingConfig    `yaml:"logging"`
+	Tracing    TracingConfig    `yaml:"tracing"`
+	Restaurant RestaurantConfig `yaml:"restaurant"`
+	Health     HealthConfig     `yaml:"health"`
+	RateLimit  RateLimitConfig  `yaml:"rateLimit"`
+	Mail       MailConfig       `yaml:"mail"`
+}
+
+type ServerConfig struct {
+	Port            int           `yaml:"port" env:"PORT"`
+	MetricsPort     int           `yaml:"metricsPort" env:"METRICS_PORT"`
+	Mode            string        `yaml:"mode" env:"GIN_MODE"`
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" env:"SHUTDOWN_TIMEOUT" unit:"s"`
+	TrustedProxies  []string      `yaml:"trustedProxies" env:"TRUSTED_PROXIES"`
+}
+
+type AuthConfig struct {
+	JWTSecret            string        `yaml:"jwtSecret" env:"JWT_SECRET"`
+	AccessTokenTTL       time.Duration `yaml:"accessTokenTTL" env:"JWT_ACCESS_TOKEN_TTL"`
+	RefreshTokenTTL      time.Duration `yaml:"refreshTokenTTL" env:"JWT_REFRESH_TOKEN_TTL"`
+	MaxLoginAttempts     int           `yaml:"maxLoginAttempts" env:"LOGIN_MAX_ATTEMPTS"`
+	LockoutDuration      time.Duration `yaml:"lockoutDuration" env:"LOGIN_LOCKOUT_DURATION"`
+	MaxLockout           time.Duration `yaml:"maxLockout" env:"LOGIN_MAX_LOCKOUT"`
+	PasswordResetTTL     time.Duration `yaml:"passwordResetTTL" env:"PASSWORD_RESET_TTL"`
+	EmailVerificationTTL time.Duration `yaml:"emailVerificationTTL" env:"EMAIL_VERIFICATION_TTL"`
+	RequireVerifiedEmail bool          `yaml:"requireVerifiedEmail" env:"REQUIRE_VERIFIED_EMAIL"`
+}
+
+type MongoConfig struct {
+	URI                    string        `yaml:"uri" env:"MONGODB_URI"`
+	Database               string        `yaml:"database" env:"MONGODB_DATABASE"`
+	ConnectTimeout         time.Duration `yaml:"connectTimeout" env:"MONGODB_CONNECT_TIMEOUT" unit:"s"`
+	ServerSelectionTimeout time.Duration `yaml:"serverSelectionTimeout" env:"MONGODB_SERVER_SELECTION_TIMEOUT" unit:"s"`
+	MaxPoolSize            uint64        `yaml:"maxPoolSize" env:"MONGODB_MAX_POOL_SIZE"`
+	MinPoolSize            uint64        `yaml:"minPoolSize" env:"MONGODB_MIN_POOL_SIZE"`
+	MaxConnIdleTime        time.Duration `yaml:"maxConnIdleTime" env:"MONGODB_MAX_CONN_IDLE_TIME" unit:"s"`
+	ConnectRetries         int           `yaml:"connectRetries" env:"MONGODB_CONNECT_RETRIES"`
+	RetryInitialBackoff    time.Duration `yaml:"retryInitialBackoff" env:"MONGODB_RETRY_INITIAL_BACKOFF_MS" unit:"ms"`
+	RetryMaxBackoff        time.Duration `yaml:"retryMaxBackoff" env:"MONGODB_RETRY_MAX_BACKOFF_MS" unit:"ms"`
+}
+
+type MigrationsConfig struct {
+	OnStartup bool          `yaml:"onStartup" env:"MIGRATE_ON_STARTUP"`
+	Timeout   time.Duration `yaml:"timeout" env:"MIGRATION_TIMEOUT" unit:"s"`
+}
+
+type SeedConfig struct {
+	FixturesFile string        `yaml:"fixturesFile" env:"SEED_FIXTURES_FILE"`
+	Timeout      time.Duration `yaml:"timeout" env:"SEED_TIMEOUT" unit:"s"`
+}
+
+type StorageConfig struct {
+	Driver          string `yaml:"driver" env:"STORAGE_DRIVER"`
+	LocalPath       string `yaml:"localPath" env:"STORAGE_LOCAL_PATH"`
+	GridFSBucket    string `yaml:"gridfsBucket" env:"STORAGE_GRIDFS_BUCKET"`
+	MaxUploadSizeMB int    `yaml:"maxUploadSizeMB" env:"IMAGE_MAX_UPLOAD_SIZE_MB"`
+}
+
+type LoggingConfig struct {
+	Level              string   `yaml:"level" env:"LOG_LEVEL"`
+	Format             string   `yaml:"format" env:"LOG_FORMAT"`
+	Outputs            []string `yaml:"outputs" env:"LOG_OUTPUTS"`
+	Sampling           bool     `yaml:"sampling" env:"LOG_SAMPLING"`
+	SamplingInitial    int      `yaml:"samplingInitial" env:"LOG_SAMPLING_INITIAL"`
+	SamplingThereafter int      `yaml:"samplingThereafter" env:"LOG_SAMPLING_THEREAFTER"`
+	FileMaxSizeMB      int      `yaml:"fileMaxSizeMB" env:"LOG_FILE_MAX_SIZE_MB"`
+	FileMaxAgeDays     int      `yaml:"fileMaxAgeDays" env:"LOG_FILE_MAX_AGE_DAYS"`
+	FileMaxBackups     int      `yaml:"fileMaxBackups" env:"LOG_FILE_MAX_BACKUPS"`
+	FileCompress       bool     `yaml:"fileCompress" env:"LOG_FILE_COMPRESS"`
+}
+
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" env:"OTEL_TRACES_EXPORTER"`
+	ServiceName string  `yaml:"serviceName" env:"OTEL_SERVICE_NAME"`
+	SampleRatio float64 `yaml:"sampleRatio" env:"OTEL_TRACES_SAMPLE_RATIO"`
+}
+
+type RestaurantConfig struct {
+	Timezone string `yaml:"timezone" env:"RESTAURANT_TIMEZONE"`
+}
+
+type HealthConfig struct {
+	CheckTimeout  time.Duration `yaml:"checkTimeout" env:"HEALTH_CHECK_TIMEOUT"`
+	ShutdownDelay time.Duration `yaml:"shutdownDelay" env:"HEALTH_SHUTDOWN_DELAY"`
+	MinFreeDiskMB uint64        `yaml:"minFreeDiskMB" env:"HEALTH_MIN_FREE_DISK_MB"`
+}
+
+type RateLimitConfig struct {
+	Enabled       bool    `yaml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	Store         string  `yaml:"store" env:"RATE_LIMIT_STORE"`
+	AuthPerMinute float64 `yaml:"authPerMinute" env:"RATE_LIMIT_AUTH_PER_MINUTE"`
+	AuthBurst     int     `yaml:"authBurst" env:"RATE_LIMIT_AUTH_BURST"`
+	APIPerMinute  float64 `yaml:"apiPerMinute" env:"RATE_LIMIT_API_PER_MINUTE"`
+	APIBurst      int     `yaml:"apiBurst" env:"RATE_LIMIT_API_BURST"`
+}
+
+type MailConfig struct {
+	Driver       string `yaml:"driver" env:"MAIL_DRIVER"`
+	From         string `yaml:"from" env:"MAIL_FROM"`
+	SMTPHost     string `yaml:"smtpHost" env:"SMTP_HOST"`
+	SMTPPort     int    `yaml:"smtpPort" env:"SMTP_PORT"`
+	SMTPUsername string `yaml:"smtpUsername" env:"SMTP_USERNAME"`
+	SMTPPassword string `yaml:"smtpPassword" env:"SMTP_PASSWORD"`
+	FileDir      string `yaml:"fileDir" env:"MAIL_FILE_DIR"`
+	LinkBaseURL  string `yaml:"linkBaseURL" env:"MAIL_LINK_BASE_URL"`
+	QueueSize    int    `yaml:"queueSize" env:"MAIL_QUEUE_SIZE"`
+}
+
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Port:            8080,
+			MetricsPort:     9090,
+			Mode:            "release",
+			ShutdownTimeout: 5 * time.Second,
+		},
+		Auth: AuthConfig{
+			AccessTokenTTL:       6 * time.Hour,
+			RefreshTokenTTL:      24 * time.Hour,
+			MaxLoginAttempts:     5,
+			LockoutDuration:      time.Minute,
+			MaxLockout:           time.Hour,
+			PasswordResetTTL:     time.Hour,
+			EmailVerificationTTL: 48 * time.Hour,
+		},
+		Mongo: MongoConfig{
+			URI:                    "mongodb://localhost:27017",
+			Database:               "restaurant",
+			ConnectTimeout:         10 * time.Second,
+			ServerSelectionTimeout: 30 * time.Second,
+			MaxPoolSize:            100,
+			MaxConnIdleTime:        300 * time.Second,
+			RetryInitialBackoff:    500 * time.Millisecond,
+			RetryMaxBackoff:        30 * time.Second,
+		},
+		Migrations: MigrationsConfig{
+			OnStartup: true,
+			Timeout:   120 * time.Second,
+		},
+		Seed: SeedConfig{
+			FixturesFile: "fixtures/seed.yaml",
+			Timeout:      600 * time.Second,
+		},
+		Storage: StorageConfig{
+			Driver:          "local",
+			LocalPath:       "./uploads",
+			GridFSBucket:    "images",
+			MaxUploadSizeMB: 5,
+		},
+		Logging: LoggingConfig{
+			Level:              "info",
+			Format:             "json",
+			Outputs:            []string{"stdout"},
+			SamplingInitial:    100,
+			SamplingThereafter: 100,
+			FileMaxSizeMB:      100,
+			FileMaxAgeDays:     7,
+			FileMaxBackups:     5,
+			FileCompress:       true,
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "restaurant-management-backend",
+			SampleRatio: 1,
+		},
+		Restaurant: RestaurantConfig{
+			Timezone: "UTC",
+		},
+		Health: HealthConfig{
+			CheckTimeout:  2 * time.Second,
+			ShutdownDelay: 5 * time.Second,
+			MinFreeDiskMB: 100,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:       true,
+			Store:         "memory",
+			AuthPerMinute: 10,
+			AuthBurst:     5,
+			APIPerMinute:  300,
+			APIBurst:      100,
+		},
+		Mail: MailConfig{
+			Driver:      "log",
+			From:        "Restaurant <no-reply@restaurant.local>",
+			SMTPPort:    587,
+			FileDir:     "./mail",
+			LinkBaseURL: "http://localhost:3000",
+			QueueSize:   100,
+		},
+	}
+}
+
+func (c Config) IsRelease() bool {
+	return c.Server.Mode == "release"
+}
+
+func (r RestaurantConfig) Location() (*time.Location, error) {
+	return time.LoadLocation(r.Timezone)
+}
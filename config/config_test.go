@@ -0,0 +1,160 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/config"
+)
+
+func writeConfigFile(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("writing config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadLayersEnvironmentOverTheFile(t *testing.T) {
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, `
+server:
+  mode: debug
+  port: 8000
+mongo:
+  database: from-file
+logging:
+  level: debug
+`))
+	t.Setenv("PORT", "8081")
+	t.Setenv("SHUTDOWN_TIMEOUT", "30")
+	t.Setenv("MONGODB_RETRY_INITIAL_BACKOFF_MS", "250")
+	t.Setenv("JWT_ACCESS_TOKEN_TTL", "2h")
+	t.Setenv("LOG_OUTPUTS", "stdout, file ,")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+
+	if cfg.Server.Port != 8081 {
+		t.Fatalf("expected PORT to override the file, got %d", cfg.Server.Port)
+	}
+	if cfg.Mongo.Database != "from-file" || cfg.Logging.Level != "debug" {
+		t.Fatalf("expected values from the file, got %q and %q", cfg.Mongo.Database, cfg.Logging.Level)
+	}
+	if cfg.Server.ShutdownTimeout != 30*time.Second || cfg.Mongo.RetryInitialBackoff != 250*time.Millisecond {
+		t.Fatalf("expected bare numbers in their declared units, got %s and %s", cfg.Server.ShutdownTimeout, cfg.Mongo.RetryInitialBackoff)
+	}
+	if cfg.Auth.AccessTokenTTL != 2*time.Hour {
+		t.Fatalf("expected a parsed duration, got %s", cfg.Auth.AccessTokenTTL)
+	}
+	if strings.Join(cfg.Logging.Outputs, "|") != "stdout|file" {
+		t.Fatalf("expected a trimmed list, got %q", cfg.Logging.Outputs)
+	}
+	if cfg.Auth.JWTSecret != config.DevelopmentJWTSecret {
+		t.Fatalf("expected the development secret outside release mode, got %q", cfg.Auth.JWTSecret)
+	}
+	if cfg.Mongo.URI != config.Default().Mongo.URI {
+		t.Fatalf("expected defaults for unset values, got %q", cfg.Mongo.URI)
+	}
+}
+
+func TestLoadRejectsBadInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		message string
+	}{
+		{"unknown field in the file", "server:\n  mode: debug\n  prot: 80\n", nil, "field prot not found"},
+		{"malformed integer", "server:\n  mode: debug\n", map[string]string{"PORT": "eighty"}, `PORT: "eighty" is not an integer`},
+		{"malformed duration", "server:\n  mode: debug\n", map[string]string{"JWT_ACCESS_TOKEN_TTL": "soon"}, "JWT_ACCESS_TOKEN_TTL"},
+		{"release mode without a secret", "server:\n  mode: release\nmail:\n  driver: smtp\n  smtpHost: smtp.example.com\n", nil, "JWT_SECRET"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CONFIG_FILE", writeConfigFile(t, tt.file))
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			_, err := config.Load()
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Fatalf("expected an error mentioning %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
+
+func releaseConfig() config.Config {
+	cfg := config.Default()
+	cfg.Auth.JWTSecret = "a-release-secret-that-is-long-enough"
+	cfg.Mail.Driver = "smtp"
+	cfg.Mail.SMTPHost = "smtp.example.com"
+	return cfg
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(*config.Config)
+		message string
+	}{
+		{"valid release config", func(*config.Config) {}, ""},
+		{"missing secret in release", func(c *config.Config) { c.Auth.JWTSecret = "" }, "JWT_SECRET) is required"},
+		{"development secret in release", func(c *config.Config) { c.Auth.JWTSecret = config.DevelopmentJWTSecret }, "must not be the development secret"},
+		{"short secret in release", func(c *config.Config) { c.Auth.JWTSecret = "too-short" }, "at least 32 characters"},
+		{"log mail driver in release", func(c *config.Config) { c.Mail.Driver = "log" }, "must be smtp in release mode"},
+		{"log mail driver in debug", func(c *config.Config) { c.Server.Mode = "debug"; c.Mail.Driver = "log" }, ""},
+		{"smtp without a host", func(c *config.Config) { c.Mail.SMTPHost = "" }, "SMTP_HOST"},
+		{"port out of range", func(c *config.Config) { c.Server.Port = 70000 }, "PORT) must be between"},
+		{"metrics on the api port", func(c *config.Config) { c.Server.MetricsPort = c.Server.Port }, "must differ from server.port"},
+		{"unknown mode", func(c *config.Config) { c.Server.Mode = "production" }, "GIN_MODE"},
+		{"refresh shorter than access", func(c *config.Config) { c.Auth.RefreshTokenTTL = time.Minute }, "JWT_REFRESH_TOKEN_TTL"},
+		{"lockout longer than the maximum", func(c *config.Config) { c.Auth.LockoutDuration = 2 * time.Hour }, "LOGIN_LOCKOUT_DURATION"},
+		{"unknown time zone", func(c *config.Config) { c.Restaurant.Timezone = "Mars/Olympus" }, "is not a known time zone"},
+		{"unknown rate limit store", func(c *config.Config) { c.RateLimit.Store = "redis" }, "RATE_LIMIT_STORE"},
+		{"rate limit store ignored when disabled", func(c *config.Config) { c.RateLimit.Enabled = false; c.RateLimit.Store = "redis" }, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := releaseConfig()
+			tt.change(&cfg)
+
+			err := cfg.Validate()
+			if tt.message == "" {
+				if err != nil {
+					t.Fatalf("expected a valid config, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Fatalf("expected an error mentioning %q, got %v", tt.message, err)
+			}
+		})
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	cfg := releaseConfig()
+	cfg.Server.Port = 0
+	cfg.Mongo.URI = ""
+	cfg.Logging.Format = "xml"
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, message := range []string{"PORT", "MONGODB_URI", "LOG_FORMAT"} {
+		if !strings.Contains(err.Error(), message) {
+			t.Fatalf("expected %s to be reported, got %v", message, err)
+		}
+	}
+}
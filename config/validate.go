@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const minReleaseSecretLength = 32
+
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Server.Port > 0 && c.Server.Port <= 65535, "server.port (PORT) must be between 1 and 65535")
+	check(c.Server.MetricsPort > 0 && c.Server.MetricsPort <= 65535, "server.metricsPort (METRICS_PORT) must be between 1 and 65535")
+	check(c.Server.MetricsPort != c.Server.Port, "server.metricsPort (METRICS_PORT) must differ from server.port (PORT)")
+	check(oneOf(c.Server.Mode, "debug", "release", "test"), "server.mode (GIN_MODE) must be one of debug, release or test")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdownTimeout (SHUTDOWN_TIMEOUT) must be positive")
+
+	if c.IsRelease() {
+		check(c.Auth.JWTSecret != "", "auth.jwtSecret (JWT_SECRET) is required in release mode")
+		check(c.Auth.JWTSecret == "" || c.Auth.JWTSecret != DevelopmentJWTSecret, "auth.jwtSecret (JWT_SECRET) must not be the development secret in release mode")
+		check(c.Auth.JWTSecret == "" || len(c.Auth.JWTSecret) >= minReleaseSecretLength, "auth.jwtSecret (JWT_SECRET) must be at least %d characters in release mode", minReleaseSecretLength)
+	}
+	check(c.Auth.AccessTokenTTL > 0, "auth.accessTokenTTL (JWT_ACCESS_TOKEN_TTL) must be positive")
+	check(c.Auth.RefreshTokenTTL >= c.Auth.AccessTokenTTL, "auth.refreshTokenTTL (JWT_REFRESH_TOKEN_TTL) must not be shorter than the access token TTL")
+
+	check(c.Auth.MaxLoginAttempts > 0, "auth.maxLoginAttempts (LOGIN_MAX_ATTEMPTS) must be positive")
+	check(c.Auth.LockoutDuration > 0 && c.Auth.LockoutDuration <= c.Auth.MaxLockout, "auth.lockoutDuration (LOGIN_LOCKOUT_DURATION) must be positive and not exceed auth.maxLockout (LOGIN_MAX_LOCKOUT)")
+
+	check(c.Auth.PasswordResetTTL > 0, "auth.passwordResetTTL (PASSWORD_RESET_TTL) must be positive")
+	check(c.Auth.EmailVerificationTTL > 0, "auth.emailVerificationTTL (EMAIL_VERIFICATION_TTL) must be positive")
+
+	check(c.Mongo.URI != "", "mongo.uri (MONGODB_URI) is required")
+	check(c.Mongo.Database != "", "mongo.database (MONGODB_DATABASE) is required")
+	check(c.Mongo.ConnectTimeout > 0, "mongo.connectTimeout (MONGODB_CONNECT_TIMEOUT) must be positive")
+	check(c.Mongo.ServerSelectionTimeout > 0, "mongo.serverSelectionTimeout (MONGODB_SERVER_SELECTION_TIMEOUT) must be positive")
+	check(c.Mongo.MaxPoolSize == 0 || c.Mongo.MinPoolSize <= c.Mongo.MaxPoolSize, "mongo.minPoolSize (MONGODB_MIN_POOL_SIZE) must not exceed mongo.maxPoolSize")
+	check(c.Mongo.ConnectRetries >= 0, "mongo.connectRetries (MONGODB_CONNECT_RETRIES) must not be negative")
+	check(c.Mongo.RetryInitialBackoff > 0 && c.Mongo.RetryInitialBackoff <= c.Mongo.RetryMaxBackoff, "mongo.retryInitialBackoff must be positive and not exceed mongo.retryMaxBackoff")
+
+	check(c.Migrations.Timeout > 0, "migrations.timeout (MIGRATION_TIMEOUT) must be positive")
+	check(c.Seed.Timeout > 0, "seed.timeout (SEED_TIMEOUT) must be positive")
+
+	check(oneOf(c.Storage.Driver, "local", "gridfs"), "storage.driver (STORAGE_DRIVER) must be local or gridfs")
+	check(c.Storage.Driver != "local" || c.Storage.LocalPath != "", "storage.localPath (STORAGE_LOCAL_PATH) is required for the local driver")
+	check(c.Storage.Driver != "gridfs" || c.Storage.GridFSBucket != "", "storage.gridfsBucket (STORAGE_GRIDFS_BUCKET) is required for the gridfs driver")
+	check(c.Storage.MaxUploadSizeMB > 0, "storage.maxUploadSizeMB (IMAGE_MAX_UPLOAD_SIZE_MB) must be positive")
+
+	check(oneOf(strings.ToLower(c.Logging.Level), "debug", "info", "warn", "error"), "logging.level (LOG_LEVEL) must be one of debug, info, warn or error")
+	check(oneOf(c.Logging.Format, "json", "console"), "logging.format (LOG_FORMAT) must be json or console")
+	check(len(c.Logging.Outputs) > 0, "logging.outputs (LOG_OUTPUTS) must list at least one output")
+	check(!c.Logging.Sampling || (c.Logging.SamplingInitial > 0 && c.Logging.SamplingThereafter > 0), "logging sampling rates must be positive")
+
+	check(oneOf(c.Tracing.Exporter, "none", "stdout", "otlp"), "tracing.exporter (OTEL_TRACES_EXPORTER) must be one of none, stdout or otlp")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sampleRatio (OTEL_TRACES_SAMPLE_RATIO) must be between 0 and 1")
+
+	check(c.Health.CheckTimeout > 0, "health.checkTimeout (HEALTH_CHECK_TIMEOUT) must be positive")
+	check(c.Health.ShutdownDelay >= 0, "health.shutdownDelay (HEALTH_SHUTDOWN_DELAY) must not be negative")
+
+	if c.RateLimit.Enabled {
+		check(oneOf(c.RateLimit.Store, "memory", "mongo"), "rateLimit.store (RATE_LIMIT_STORE) must be memory or mongo")
+		check(c.RateLimit.AuthPerMinute > 0 && c.RateLimit.AuthBurst > 0, "rateLimit.authPerMinute and rateLimit.authBurst (RATE_LIMIT_AUTH_*) must be positive")
+		check(c.RateLimit.APIPerMinute > 0 && c.RateLimit.APIBurst > 0, "rateLimit.apiPerMinute and rateLimit.apiBurst (RATE_LIMIT_API_*) must be positive")
+	}
+
+	check(oneOf(c.Mail.Driver, "smtp", "file", "log"), "mail.driver (MAIL_DRIVER) must be one of smtp, file or log")
+	if c.IsRelease() {
+		check(c.Mail.Driver == "smtp", "mail.driver (MAIL_DRIVER) must be smtp in release mode; the file and log drivers expose account tokens")
+	}
+	check(c.Mail.From != "", "mail.from (MAIL_FROM) is required")
+	check(c.Mail.Driver != "smtp" || c.Mail.SMTPHost != "", "mail.smtpHost (SMTP_HOST) is required for the smtp driver")
+	check(c.Mail.Driver != "smtp" || (c.Mail.SMTPPort > 0 && c.Mail.SMTPPort <= 65535), "mail.smtpPort (SMTP_PORT) must be between 1 and 65535")
+	check(c.Mail.Driver != "file" || c.Mail.FileDir != "", "mail.fileDir (MAIL_FILE_DIR) is required for the file driver")
+	check(c.Mail.LinkBaseURL != "", "mail.linkBaseURL (MAIL_LINK_BASE_URL) is required")
+	check(c.Mail.QueueSize > 0, "mail.queueSize (MAIL_QUEUE_SIZE) must be positive")
+
+	if _, err := c.Restaurant.Location(); err != nil {
+		errs = append(errs, fmt.Errorf("restaurant.timezone (RESTAURANT_TIMEZONE) %q is not a known time zone", c.Restaurant.Timezone))
+	}
+
+	return errors.Join(errs...)
+}
+
+func oneOf(value string, allowed ...string) bool {
+	for _, candidate := range allowed {
+		if value == candidate {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type EmailRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type TokenRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=6"`
+}
+
+func (ctrl *UserController) ForgotPassword() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		var request EmailRequest
+		if !bindAccountRequest(c, &request) {
+			return
+		}
+
+		user, err := ctrl.users.FindByEmail(ctx, request.Email)
+		if err == nil && !user.Deactivated {
+			if err := ctrl.accounts.SendPasswordReset(ctx, *user); err != nil {
+				utils.LoggerFrom(ctx).Error("Failed to send password reset email", zap.String("userId", user.UserID), zap.Error(err))
+			}
+		} else if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Internal(c, "Error looking up user", err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"message": "If an account with that email exists, a password reset link has been sent"})
+	}
+}
+
+func (ctrl *UserController) ResetPassword() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		var request ResetPasswordRequest
+		if !bindAccountRequest(c, &request) {
+			return
+		}
+
+		token, ok := ctrl.redeem(c, ctx, request.Token, models.UserTokenPasswordReset)
+		if !ok {
+			return
+		}
+
+		user, err := ctrl.users.FindByID(ctx, token.UserID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "User not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Error looking up user", err)
+			return
+		} else if user.Deactivated {
+			apierrors.New(http.StatusForbidden, "This account has been deactivated").WithCode("account_deactivated").Send(c)
+			return
+		}
+
+		hashedPassword, err := helper.HashPassword(&request.Password)
+		if err != nil {
+			apierrors.Internal(c, "Error hashing password", err)
+			return
+		}
+
+		if err := ctrl.users.UpdatePassword(ctx, token.UserID, *hashedPassword); errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "User not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Error updating password", err)
+			return
+		}
+
+		utils.LoggerFrom(ctx).Info("Password reset", zap.String("userId", token.UserID))
+		c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+	}
+}
+
+func (ctrl *UserController) VerifyEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		var request TokenRequest
+		if !bindAccountRequest(c, &request) {
+			return
+		}
+
+		token, ok := ctrl.redeem(c, ctx, request.Token, models.UserTokenEmailVerification)
+		if !ok {
+			return
+		}
+
+		if err := ctrl.users.MarkEmailVerified(ctx, token.UserID, time.Now().UTC()); errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "User not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Error verifying email", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
+	}
+}
+
+func (ctrl *UserController) ResendVerification() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		var request EmailRequest
+		if !bindAccountRequest(c, &request) {
+			return
+		}
+
+		user, err := ctrl.users.FindByEmail(ctx, request.Email)
+		if err == nil && !user.EmailVerified {
+			if err := ctrl.accounts.SendEmailVerification(ctx, *user); err != nil {
+				utils.LoggerFrom(ctx).Error("Failed to send verification email", zap.String("userId", user.UserID), zap.Error(err))
+			}
+		} else if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Internal(c, "Error looking up user", err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"message": "If an unverified account with that email exists, a verification link has been sent"})
+	}
+}
+
+func (ctrl *UserController) redeem(c *gin.Context, ctx context.Context, token, purpose string) (*models.UserToken, bool) {
+	redeemed, err := ctrl.accounts.Redeem(ctx, token, purpose)
+	if errors.Is(err, repositories.ErrNotFound) {
+		apierrors.New(http.StatusBadRequest, "The token is invalid, has expired or has already been used").WithCode("invalid_token").Send(c)
+		return nil, false
+	} else if err != nil {
+		apierrors.Internal(c, "Error redeeming token", err)
+		return nil, false
+	}
+	return redeemed, true
+}
+
+func bindAccountRequest(c *gin.Context, request interface{}) bool {
+	if err := c.ShouldBindJSON(request); err != nil {
+		apierrors.InvalidBody(c, err)
+		return false
+	}
+	if err := validate.Struct(request); err != nil {
+		apierrors.Validation(c, err)
+		return false
+	}
+	return true
+}
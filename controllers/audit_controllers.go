@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditController struct {
+	auditLogs repositories.AuditRepository
+}
+
+func NewAuditController(auditLogs repositories.AuditRepository) *AuditController {
+	return &AuditController{auditLogs: auditLogs}
+}
+
+var auditListSpec = helper.ListSpec{
+	Filters: map[string]helper.FieldType{
+		"action":    helper.StringField,
+		"userEmail": helper.StringField,
+		"clientIp":  helper.StringField,
+		"timestamp": helper.TimeField,
+	},
+	Sorts:       []string{"timestamp"},
+	DefaultSort: "-timestamp",
+	Params:      []string{"userId", "resourceType", "resourceId", "from", "to"},
+}
+
+func (ctrl *AuditController) GetAuditLogs() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		filter := repositories.AuditFilter{
+			UserID:       c.Query("userId"),
+			ResourceType: c.Query("resourceType"),
+			ResourceID:   c.Query("resourceId"),
+		}
+
+		if rawFrom := c.Query("from"); rawFrom != "" {
+			from, err := time.Parse(time.RFC3339, rawFrom)
+			if err != nil {
+				apierrors.Write(c, http.StatusBadRequest, "from must be an RFC3339 timestamp")
+				return
+			}
+			from = from.UTC()
+			filter.From = &from
+		}
+
+		if rawTo := c.Query("to"); rawTo != "" {
+			to, err := time.Parse(time.RFC3339, rawTo)
+			if err != nil {
+				apierrors.Write(c, http.StatusBadRequest, "to must be an RFC3339 timestamp")
+				return
+			}
+			to = to.UTC()
+			filter.To = &to
+		}
+
+		query, err := helper.ParseListQuery(c, auditListSpec)
+		if err != nil {
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		entries, total, err := ctrl.auditLogs.List(ctx, filter, query)
+		if err != nil {
+			apierrors.Internal(c, "Failed to retrieve audit logs", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, helper.NewListResponse(c, entries, total, query))
+	}
+}
@@ -0,0 +1,372 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type BundleController struct {
+	bundles repositories.BundleRepository
+	foods   repositories.FoodRepository
+	menus   repositories.MenuRepository
+}
+
+func NewBundleController(bundles repositories.BundleRepository, foods repositories.FoodRepository, menus repositories.MenuRepository) *BundleController {
+	return &BundleController{bundles: bundles, foods: foods, menus: menus}
+}
+
+func (ctrl *BundleController) CreateBundle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		var bundle models.Bundle
+		if err := c.ShouldBindJSON(&bundle); err != nil {
+			apierrors.InvalidBody(c, err)
+			return
+		}
+
+		if err := validate.Struct(bundle); err != nil {
+			apierrors.Validation(c, err)
+			return
+		}
+
+		if status, err := ctrl.prepareBundleComponents(ctx, bundle.Components); err != nil {
+			apierrors.Write(c, status, err.Error())
+			return
+		}
+
+		if bundle.MenuID != nil {
+			_, err := ctrl.menus.FindByID(ctx, *bundle.MenuID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				apierrors.Write(c, http.StatusNotFound, "Menu not found")
+				return
+			} else if err != nil {
+				apierrors.Internal(c, "Error fetching menu", err)
+				return
+			}
+		}
+
+		if bundle.Active == nil {
+			active := true
+			bundle.Active = &active
+		}
+
+		roundedPrice := helper.ToFixed(*bundle.Price, 2)
+		bundle.Price = &roundedPrice
+
+		currentTime := time.Now().UTC()
+		bundle.CreatedAt = currentTime
+		bundle.UpdatedAt = currentTime
+		bundle.ID = primitive.NewObjectID()
+		bundle.BundleID = bundle.ID.Hex()
+
+		if err := ctrl.bundles.Create(ctx, &bundle); err != nil {
+			apierrors.Internal(c, "Failed to create bundle", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Bundle created successfully", "bundle": bundle})
+	}
+}
+
+var bundleListSpec = helper.ListSpec{
+	Filters: map[string]helper.FieldType{
+		"name":      helper.StringField,
+		"menuId":    helper.StringField,
+		"price":     helper.NumberField,
+		"active":    helper.BoolField,
+		"createdAt": helper.TimeField,
+	},
+	Sorts:       []string{"name", "price", "createdAt"},
+	DefaultSort: "-createdAt",
+}
+
+func (ctrl *BundleController) GetAllBundles() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		ctx, err := helper.DeletedScope(ctx, c)
+		if err != nil {
+			apierrors.Write(c, http.StatusForbidden, err.Error())
+			return
+		}
+
+		query, err := helper.ParseListQuery(c, bundleListSpec)
+		if err != nil {
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		bundles, total, err := ctrl.bundles.List(ctx, query)
+		if err != nil {
+			apierrors.Internal(c, "Failed to retrieve bundles", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, helper.NewListResponse(c, bundles, total, query))
+	}
+}
+
+func (ctrl *BundleController) GetBundleByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		ctx, err := helper.DeletedScope(ctx, c)
+		if err != nil {
+			apierrors.Write(c, http.StatusForbidden, err.Error())
+			return
+		}
+
+		bundleID := c.Param("bundleId")
+
+		bundle, err := ctrl.bundles.FindByID(ctx, bundleID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Bundle not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve bundle", err)
+			return
+		}
+
+		helper.SetETag(c, bundle.Version)
+		c.JSON(http.StatusOK, gin.H{"bundle": bundle})
+	}
+}
+
+func (ctrl *BundleController) UpdateBundleByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		bundleID := c.Param("bundleId")
+
+		var bundle models.Bundle
+		if err := c.ShouldBindJSON(&bundle); err != nil {
+			apierrors.InvalidBody(c, err)
+			return
+		}
+
+		existing, err := ctrl.bundles.FindByID(ctx, bundleID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Bundle not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve bundle", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, existing.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Bundle has been modified; fetch it again and retry")
+			return
+		}
+
+		updated := false
+
+		if bundle.Name != nil {
+			if err := validate.Var(*bundle.Name, "min=2,max=100"); err != nil {
+				apierrors.FieldValidation(c, "name", err)
+				return
+			}
+			existing.Name = bundle.Name
+			updated = true
+		}
+
+		if bundle.Description != nil {
+			existing.Description = bundle.Description
+			updated = true
+		}
+
+		if bundle.Price != nil {
+			roundedPrice := helper.ToFixed(*bundle.Price, 2)
+			existing.Price = &roundedPrice
+			updated = true
+		}
+
+		if bundle.Components != nil {
+			if err := validate.Var(bundle.Components, "min=1,dive"); err != nil {
+				apierrors.FieldValidation(c, "components", err)
+				return
+			}
+			if status, err := ctrl.prepareBundleComponents(ctx, bundle.Components); err != nil {
+				apierrors.Write(c, status, err.Error())
+				return
+			}
+			existing.Components = bundle.Components
+			updated = true
+		}
+
+		if bundle.MenuID != nil {
+			_, err := ctrl.menus.FindByID(ctx, *bundle.MenuID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				apierrors.Write(c, http.StatusNotFound, "Menu not found")
+				return
+			} else if err != nil {
+				apierrors.Internal(c, "Error fetching menu", err)
+				return
+			}
+			existing.MenuID = bundle.MenuID
+			updated = true
+		}
+
+		if bundle.Active != nil {
+			existing.Active = bundle.Active
+			updated = true
+		}
+
+		if !updated {
+			apierrors.Write(c, http.StatusBadRequest, "No fields to update")
+			return
+		}
+
+		existing.UpdatedAt = time.Now().UTC()
+
+		if err := ctrl.bundles.Update(ctx, existing); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Bundle has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to update bundle", err)
+			return
+		}
+
+		helper.SetETag(c, existing.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Bundle updated successfully", "bundle": existing})
+	}
+}
+
+func (ctrl *BundleController) prepareBundleComponents(ctx context.Context, components []models.BundleComponent) (int, error) {
+	foodIDs := make(map[string]bool)
+	for i := range components {
+		if components[i].ComponentID == "" {
+			components[i].ComponentID = primitive.NewObjectID().Hex()
+		}
+		for _, foodID := range components[i].FoodIDs {
+			foodIDs[foodID] = true
+		}
+	}
+
+	if err := helper.ValidateBundleComponents(components); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	ids := make([]string, 0, len(foodIDs))
+	for foodID := range foodIDs {
+		ids = append(ids, foodID)
+	}
+
+	foods, err := ctrl.foods.FindByIDs(ctx, ids)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("error fetching bundle foods")
+	}
+	if len(foods) != len(ids) {
+		return http.StatusNotFound, fmt.Errorf("one or more bundle foods were not found")
+	}
+
+	return http.StatusOK, nil
+}
+
+func (ctrl *BundleController) DeleteBundleByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		bundleID := c.Param("bundleId")
+
+		bundle, err := ctrl.bundles.FindByID(ctx, bundleID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Bundle not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve bundle", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, bundle.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Bundle has been modified; fetch it again and retry")
+			return
+		}
+
+		deletedAt, deletedBy := helper.DeletionStamp(c)
+
+		bundle.DeletedAt = &deletedAt
+		bundle.DeletedBy = deletedBy
+		bundle.UpdatedAt = deletedAt
+
+		if err := ctrl.bundles.Update(ctx, bundle); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Bundle has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to delete bundle", err)
+			return
+		}
+
+		helper.SetETag(c, bundle.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Bundle deleted successfully", "bundle": bundle})
+	}
+}
+
+func (ctrl *BundleController) RestoreBundleByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		bundleID := c.Param("bundleId")
+
+		bundle, err := ctrl.bundles.FindByID(repositories.WithDeleted(ctx), bundleID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Bundle not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve bundle", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, bundle.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Bundle has been modified; fetch it again and retry")
+			return
+		}
+
+		if bundle.DeletedAt == nil {
+			apierrors.Write(c, http.StatusConflict, "Bundle is not deleted")
+			return
+		}
+
+		if bundle.MenuID != nil {
+			_, err := ctrl.menus.FindByID(ctx, *bundle.MenuID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				apierrors.Write(c, http.StatusConflict, "The menu of this bundle is deleted; restore it first")
+				return
+			} else if err != nil {
+				apierrors.Internal(c, "Failed to retrieve menu", err)
+				return
+			}
+		}
+
+		bundle.DeletedAt = nil
+		bundle.DeletedBy = nil
+		bundle.UpdatedAt = time.Now().UTC()
+
+		if err := ctrl.bundles.Update(ctx, bundle); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Bundle has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to restore bundle", err)
+			return
+		}
+
+		helper.SetETag(c, bundle.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Bundle restored successfully", "bundle": bundle})
+	}
+}
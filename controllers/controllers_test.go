@@ -0,0 +1,173 @@
+package controllers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/config"
+	"github.com/datarohit/go-restaurant-management-backend-project/controllers"
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/mailer"
+	"github.com/datarohit/go-restaurant-management-backend-project/middlewares"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/datarohit/go-restaurant-management-backend-project/routes"
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap/zapcore"
+)
+
+type testServer struct {
+	t      *testing.T
+	router *gin.Engine
+	repos  *repositories.Repositories
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	utils.LogLevel().SetLevel(zapcore.ErrorLevel)
+
+	cfg := config.Default()
+	cfg.Auth.JWTSecret = "a-test-secret-that-is-long-enough-to-use"
+
+	repos := repositories.NewMemoryRepositories()
+	location, _ := cfg.Restaurant.Location()
+	tokens := helper.NewTokenManager(cfg.Auth)
+	evaluator := helper.NewPromotionEvaluator(repos.OrderItems, repos.Foods, repos.Menus, repos.Promotions, location)
+	accounts := helper.NewAccountMailer(repos.UserTokens, mailer.NewQueue(mailer.NewMailer(cfg.Mail), cfg.Mail.QueueSize), cfg.Mail, cfg.Auth)
+
+	router := gin.New()
+	router.Use(middlewares.AuditActor())
+	router.NoRoute(middlewares.NoRoute())
+
+	userController := controllers.NewUserController(repos.Users, tokens, helper.NewLoginLockout(repos.Users, cfg.Auth), accounts, false)
+	foodController := controllers.NewFoodController(repos.Foods, repos.Menus, repos.FoodPrices, repos.Bundles)
+	routes.UserRoutes(router, userController)
+	routes.GuestRoutes(router, foodController)
+
+	router.Use(middlewares.Authentication(tokens, repos.Users))
+
+	routes.ProfileRoutes(router, userController)
+	routes.MenuRoutes(router, controllers.NewMenuController(repos.Menus, repos.Foods, repos.Bundles))
+	routes.TableRoutes(router, controllers.NewTableController(repos.Tables))
+	routes.OrderRoutes(router, controllers.NewOrderController(repos.Orders, repos.Tables, repos.OrderItems, repos.Foods, repos.Invoices))
+	routes.InvoiceRoutes(router, controllers.NewInvoiceController(repos.Invoices, repos.Orders, repos.Tables, repos.OrderItems, evaluator))
+	routes.PromotionRoutes(router, controllers.NewPromotionController(repos.Promotions, repos.Orders, evaluator))
+	routes.OrderItemRoutes(router, controllers.NewOrderItemController(repos.Orders, repos.OrderItems, repos.Foods, repos.FoodPrices, repos.Bundles, repos.Invoices, evaluator))
+
+	return &testServer{t: t, router: router, repos: repos}
+}
+
+type request struct {
+	method  string
+	path    string
+	body    interface{}
+	token   string
+	headers map[string]string
+}
+
+func (s *testServer) do(r request) *httptest.ResponseRecorder {
+	s.t.Helper()
+
+	var body bytes.Buffer
+	if r.body != nil {
+		if err := json.NewEncoder(&body).Encode(r.body); err != nil {
+			s.t.Fatalf("encoding request body: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(r.method, r.path, &body)
+	req.Header.Set("Content-Type", "application/json")
+	if r.token != "" {
+		req.Header.Set("Authorization", r.token)
+	}
+	for key, value := range r.headers {
+		req.Header.Set(key, value)
+	}
+
+	recorder := httptest.NewRecorder()
+	s.router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// createUser stores a verified user directly in the repository and returns its ID.
+// The email doubles as the phone number so that both stay unique.
+func (s *testServer) createUser(email, password, role string) string {
+	s.t.Helper()
+
+	hashedPassword, err := helper.HashPassword(&password)
+	if err != nil {
+		s.t.Fatalf("hashing password: %v", err)
+	}
+
+	now := time.Now().UTC()
+	firstName, lastName, phone := "Test", "User", email
+	user := models.User{
+		ID:            primitive.NewObjectID(),
+		FirstName:     &firstName,
+		LastName:      &lastName,
+		Email:         &email,
+		Phone:         &phone,
+		Password:      hashedPassword,
+		Role:          &role,
+		EmailVerified: true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	user.UserID = user.ID.Hex()
+
+	if err := s.repos.Users.Create(context.Background(), &user); err != nil {
+		s.t.Fatalf("creating user: %v", err)
+	}
+	return user.UserID
+}
+
+func (s *testServer) login(email, password string) string {
+	s.t.Helper()
+
+	res := s.do(request{method: http.MethodPost, path: "/api/v1/users/login", body: gin.H{"email": email, "password": password}})
+	expectStatus(s.t, res, http.StatusOK)
+
+	var auth models.AuthResponse
+	decode(s.t, res, &auth)
+	return auth.AccessToken
+}
+
+func (s *testServer) adminToken() string {
+	s.t.Helper()
+	s.createUser("admin@example.com", "admin-secret", models.UserRoleAdmin)
+	return s.login("admin@example.com", "admin-secret")
+}
+
+func expectStatus(t *testing.T, res *httptest.ResponseRecorder, status int) {
+	t.Helper()
+	if res.Code != status {
+		t.Fatalf("expected status %d, got %d: %s", status, res.Code, res.Body.String())
+	}
+}
+
+func expectCode(t *testing.T, res *httptest.ResponseRecorder, code string) {
+	t.Helper()
+
+	var problem struct {
+		Code string `json:"code"`
+	}
+	decode(t, res, &problem)
+	if problem.Code != code {
+		t.Fatalf("expected problem code %q, got %q: %s", code, problem.Code, res.Body.String())
+	}
+}
+
+func decode(t *testing.T, res *httptest.ResponseRecorder, target interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(res.Body.Bytes(), target); err != nil {
+		t.Fatalf("decoding response %q: %v", res.Body.String(), err)
+	}
+}
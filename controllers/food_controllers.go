@@ -2,44 +2,56 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
-	"github.com/datarohit/go-restaurant-management-backend-project/database"
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
 	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
 	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-var foodCollection *mongo.Collection = database.OpenCollection(database.Client, "food")
+type FoodController struct {
+	foods   repositories.FoodRepository
+	menus   repositories.MenuRepository
+	prices  repositories.FoodPriceRepository
+	bundles repositories.BundleRepository
+}
+
+func NewFoodController(foods repositories.FoodRepository, menus repositories.MenuRepository, prices repositories.FoodPriceRepository, bundles repositories.BundleRepository) *FoodController {
+	return &FoodController{foods: foods, menus: menus, prices: prices, bundles: bundles}
+}
 
-func CreateFood() gin.HandlerFunc {
+func (ctrl *FoodController) CreateFood() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
 		var food models.Food
-		if err := c.BindJSON(&food); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		if err := c.ShouldBindJSON(&food); err != nil {
+			apierrors.InvalidBody(c, err)
 			return
 		}
 
+		food.FoodImage = nil
+		food.Thumbnails = nil
+		food.Allergens = helper.NormalizeFoodLabels(food.Allergens)
+		food.DietaryFlags = helper.NormalizeFoodLabels(food.DietaryFlags)
+
 		if err := validate.Struct(food); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			apierrors.Validation(c, err)
 			return
 		}
 
-		var menu models.Menu
-		err := menuCollection.FindOne(ctx, bson.M{"menuId": food.MenuID}).Decode(&menu)
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Menu not found"})
+		_, err := ctrl.menus.FindByID(ctx, *food.MenuID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Menu not found")
 			return
 		} else if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching menu"})
+			apierrors.Internal(c, "Error fetching menu", err)
 			return
 		}
 
@@ -51,9 +63,13 @@ func CreateFood() gin.HandlerFunc {
 		roundedPrice := helper.ToFixed(*food.Price, 2)
 		food.Price = &roundedPrice
 
-		_, err = foodCollection.InsertOne(ctx, food)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create food item"})
+		if err := ctrl.foods.Create(ctx, &food); err != nil {
+			apierrors.Internal(c, "Failed to create food item", err)
+			return
+		}
+
+		if _, err := helper.RecordFoodPrice(ctx, ctrl.prices, food.FoodID, *food.Price, food.CreatedAt); err != nil {
+			apierrors.Internal(c, "Failed to record food price", err)
 			return
 		}
 
@@ -61,126 +77,401 @@ func CreateFood() gin.HandlerFunc {
 	}
 }
 
-func GetAllFoodItems() gin.HandlerFunc {
+var foodListSpec = helper.ListSpec{
+	Filters: map[string]helper.FieldType{
+		"name":      helper.StringField,
+		"price":     helper.NumberField,
+		"menuId":    helper.StringField,
+		"createdAt": helper.TimeField,
+		"updatedAt": helper.TimeField,
+	},
+	Sorts:       []string{"name", "price", "createdAt", "updatedAt"},
+	DefaultSort: "-createdAt",
+	Params:      []string{"excludeAllergens", "dietary"},
+}
+
+func (ctrl *FoodController) GetAllFoodItems() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
-		recordPerPage, page := helper.GetPaginationParams(c)
+		ctx, err := helper.DeletedScope(ctx, c)
+		if err != nil {
+			apierrors.Write(c, http.StatusForbidden, err.Error())
+			return
+		}
+
+		filter, err := helper.GetFoodFilterParams(c)
+		if err != nil {
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		query, err := helper.ParseListQuery(c, foodListSpec)
+		if err != nil {
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		foodItems, total, err := ctrl.foods.List(ctx, filter, query)
+		if err != nil {
+			apierrors.Internal(c, "Failed to retrieve food items", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, helper.NewListResponse(c, foodItems, total, query))
+	}
+}
+
+func (ctrl *FoodController) GetFoodsByMenuID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		ctx, err := helper.DeletedScope(ctx, c)
+		if err != nil {
+			apierrors.Write(c, http.StatusForbidden, err.Error())
+			return
+		}
+
+		menuID := c.Param("menuId")
+		if menuID == "" {
+			apierrors.Write(c, http.StatusBadRequest, "menuId parameter is required")
+			return
+		}
+
+		_, err = ctrl.menus.FindByID(ctx, menuID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Menu not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Error fetching menu", err)
+			return
+		}
+
+		filter, err := helper.GetFoodFilterParams(c)
+		if err != nil {
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		filter.MenuID = menuID
 
-		skip := (page - 1) * recordPerPage
-		foodItems, totalCount, err := helper.GetPaginatedFoodItems(ctx, skip, recordPerPage)
+		query, err := helper.ParseListQuery(c, foodListSpec)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve users"})
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"totalCount": totalCount,
-			"foodItems":  foodItems,
-		})
+		foodItems, total, err := ctrl.foods.List(ctx, filter, query)
+		if err != nil {
+			apierrors.Internal(c, "Failed to retrieve food items", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, helper.NewListResponse(c, foodItems, total, query))
 	}
 }
 
-func GetFoodByID() gin.HandlerFunc {
+func (ctrl *FoodController) GetFoodByID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
+		ctx, err := helper.DeletedScope(ctx, c)
+		if err != nil {
+			apierrors.Write(c, http.StatusForbidden, err.Error())
+			return
+		}
+
 		foodID := c.Param("foodId")
 		if foodID == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "foodId parameter is required"})
+			apierrors.Write(c, http.StatusBadRequest, "foodId parameter is required")
 			return
 		}
 
-		var food models.Food
-		err := foodCollection.FindOne(ctx, bson.M{"foodId": foodID}).Decode(&food)
+		food, err := ctrl.foods.FindByID(ctx, foodID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Food item not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Error occurred while fetching the food item", err)
+			return
+		}
+
+		effectivePrice, err := helper.GetEffectivePrice(ctx, ctrl.prices, *food, time.Now().UTC())
 		if err != nil {
-			if err == mongo.ErrNoDocuments {
-				c.JSON(http.StatusNotFound, gin.H{"error": "Food item not found"})
-				return
-			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error occurred while fetching the food item"})
+			apierrors.Internal(c, "Failed to resolve effective price", err)
 			return
 		}
+		food.Price = effectivePrice
 
+		helper.SetETag(c, food.Version)
 		c.JSON(http.StatusOK, gin.H{"food": food})
 	}
 }
 
-func UpdateFoodByID() gin.HandlerFunc {
+func (ctrl *FoodController) UpdateFoodByID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
 		foodID := c.Param("foodId")
 		if foodID == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "foodId parameter is required"})
+			apierrors.Write(c, http.StatusBadRequest, "foodId parameter is required")
 			return
 		}
 
 		var food models.Food
-		if err := c.BindJSON(&food); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		if err := c.ShouldBindJSON(&food); err != nil {
+			apierrors.InvalidBody(c, err)
+			return
+		}
+
+		existing, err := ctrl.foods.FindByID(ctx, foodID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Food item not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Error occurred while fetching the food item", err)
 			return
 		}
 
-		updateObj := bson.D{}
+		if !helper.CheckIfMatch(c, existing.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Food has been modified; fetch it again and retry")
+			return
+		}
+
+		updated := false
 
 		if food.Name != nil {
-			updateObj = append(updateObj, bson.E{Key: "name", Value: food.Name})
+			existing.Name = food.Name
+			updated = true
 		}
 
-		if food.Price != nil {
-			roundedPrice := helper.ToFixed(*food.Price, 2)
-			updateObj = append(updateObj, bson.E{Key: "price", Value: roundedPrice})
+		if food.Description != nil {
+			existing.Description = food.Description
+			updated = true
+		}
+
+		if food.Allergens != nil {
+			allergens := helper.NormalizeFoodLabels(food.Allergens)
+			if err := validate.Var(allergens, "dive,allergen"); err != nil {
+				apierrors.FieldValidation(c, "allergens", err)
+				return
+			}
+			existing.Allergens = allergens
+			updated = true
+		}
+
+		if food.DietaryFlags != nil {
+			dietaryFlags := helper.NormalizeFoodLabels(food.DietaryFlags)
+			if err := validate.Var(dietaryFlags, "dive,dietaryflag"); err != nil {
+				apierrors.FieldValidation(c, "dietaryFlags", err)
+				return
+			}
+			existing.DietaryFlags = dietaryFlags
+			updated = true
+		}
+
+		if food.Nutrition != nil {
+			if err := validate.Struct(food.Nutrition); err != nil {
+				apierrors.Validation(c, err)
+				return
+			}
+			existing.Nutrition = food.Nutrition
+			updated = true
 		}
 
-		if food.FoodImage != nil {
-			updateObj = append(updateObj, bson.E{Key: "foodImage", Value: food.FoodImage})
+		if food.Price != nil {
+			roundedPrice := helper.ToFixed(*food.Price, 2)
+			existing.Price = &roundedPrice
+			updated = true
 		}
 
 		if food.MenuID != nil {
-			var menu models.Menu
-			err := menuCollection.FindOne(ctx, bson.M{"menuId": food.MenuID}).Decode(&menu)
-			if err == mongo.ErrNoDocuments {
-				c.JSON(http.StatusNotFound, gin.H{"error": "Menu not found"})
+			_, err := ctrl.menus.FindByID(ctx, *food.MenuID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				apierrors.Write(c, http.StatusNotFound, "Menu not found")
 				return
 			} else if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching menu"})
+				apierrors.Internal(c, "Error fetching menu", err)
 				return
 			}
-			updateObj = append(updateObj, bson.E{Key: "menuId", Value: food.MenuID})
+			existing.MenuID = food.MenuID
+			updated = true
 		}
 
-		if len(updateObj) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "No fields to update"})
+		if !updated {
+			apierrors.Write(c, http.StatusBadRequest, "No fields to update")
 			return
 		}
 
-		food.UpdatedAt = time.Now().UTC()
-		updateObj = append(updateObj, bson.E{Key: "updatedAt", Value: food.UpdatedAt})
+		existing.UpdatedAt = time.Now().UTC()
 
-		filter := bson.M{"foodId": foodID}
-		opts := options.Update().SetUpsert(false)
+		err = ctrl.foods.Update(ctx, existing)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Food item not found")
+			return
+		} else if errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Food has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to update food item", err)
+			return
+		}
 
-		result, err := foodCollection.UpdateOne(ctx, filter, bson.D{{Key: "$set", Value: updateObj}}, opts)
+		if food.Price != nil {
+			if _, err := helper.RecordFoodPrice(ctx, ctrl.prices, foodID, *existing.Price, existing.UpdatedAt); err != nil {
+				apierrors.Internal(c, "Failed to record food price", err)
+				return
+			}
+		}
+
+		helper.SetETag(c, existing.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Food item updated successfully", "food": existing})
+	}
+}
+
+func (ctrl *FoodController) DeleteFoodByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		foodID := c.Param("foodId")
+
+		food, err := ctrl.foods.FindByID(ctx, foodID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Food not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve food", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, food.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Food has been modified; fetch it again and retry")
+			return
+		}
+
+		bundles, err := ctrl.bundles.ListByFood(ctx, foodID)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update food item"})
+			apierrors.Internal(c, "Failed to check the bundles of this food", err)
+			return
+		}
+
+		if len(bundles) > 0 && !helper.IsCascade(c) {
+			apierrors.New(http.StatusConflict, "Food is still part of bundles; pass cascade=true to delete them as well").
+				With("bundleCount", len(bundles)).
+				Send(c)
+			return
+		}
+
+		deletedAt, deletedBy := helper.DeletionStamp(c)
+
+		for i := range bundles {
+			bundles[i].DeletedAt = &deletedAt
+			bundles[i].DeletedBy = deletedBy
+			bundles[i].UpdatedAt = deletedAt
+			if err := ctrl.bundles.Update(ctx, &bundles[i]); err != nil {
+				apierrors.Internal(c, "Failed to delete the bundles of this food", err)
+				return
+			}
+		}
+
+		food.DeletedAt = &deletedAt
+		food.DeletedBy = deletedBy
+		food.UpdatedAt = deletedAt
+
+		if err := ctrl.foods.Update(ctx, food); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Food has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to delete food", err)
 			return
 		}
 
-		if result.MatchedCount == 0 {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Food item not found"})
+		helper.SetETag(c, food.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Food deleted successfully", "food": food})
+	}
+}
+
+func (ctrl *FoodController) RestoreFoodByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		foodID := c.Param("foodId")
+
+		food, err := ctrl.foods.FindByID(repositories.WithDeleted(ctx), foodID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Food not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve food", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, food.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Food has been modified; fetch it again and retry")
 			return
 		}
 
-		var updatedFood models.Food
-		if err := foodCollection.FindOne(ctx, filter).Decode(&updatedFood); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve updated food item"})
+		if food.DeletedAt == nil {
+			apierrors.Write(c, http.StatusConflict, "Food is not deleted")
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"message": "Food item updated successfully", "food": updatedFood})
+		if food.MenuID != nil {
+			_, err := ctrl.menus.FindByID(ctx, *food.MenuID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				apierrors.Write(c, http.StatusConflict, "The menu of this food is deleted; restore it first")
+				return
+			} else if err != nil {
+				apierrors.Internal(c, "Failed to retrieve menu", err)
+				return
+			}
+		}
+
+		deletedAt := *food.DeletedAt
+		food.DeletedAt = nil
+		food.DeletedBy = nil
+		food.UpdatedAt = time.Now().UTC()
+
+		if err := ctrl.foods.Update(ctx, food); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Food has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to restore food", err)
+			return
+		}
+
+		bundles, err := ctrl.bundles.ListByFood(repositories.WithDeleted(ctx), foodID)
+		if err != nil {
+			apierrors.Internal(c, "Failed to retrieve the bundles of this food", err)
+			return
+		}
+		for i := range bundles {
+			if !helper.DeletedWith(bundles[i].DeletedAt, deletedAt) {
+				continue
+			}
+			if bundles[i].MenuID != nil {
+				if _, err := ctrl.menus.FindByID(ctx, *bundles[i].MenuID); errors.Is(err, repositories.ErrNotFound) {
+					continue
+				} else if err != nil {
+					apierrors.Internal(c, "Failed to retrieve menu", err)
+					return
+				}
+			}
+			bundles[i].DeletedAt = nil
+			bundles[i].DeletedBy = nil
+			bundles[i].UpdatedAt = food.UpdatedAt
+			if err := ctrl.bundles.Update(ctx, &bundles[i]); err != nil {
+				apierrors.Internal(c, "Failed to restore the bundles of this food", err)
+				return
+			}
+		}
+
+		helper.SetETag(c, food.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Food restored successfully", "food": food})
 	}
 }
@@ -0,0 +1,59 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type foodListResponse struct {
+	Items []models.Food `json:"items"`
+	Total int64         `json:"total"`
+}
+
+func (s *testServer) createMenuWithFood(name string) (string, string) {
+	s.t.Helper()
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	menu := models.Menu{ID: primitive.NewObjectID(), Name: name, Category: "Mains", CreatedAt: now, UpdatedAt: now}
+	menu.MenuID = menu.ID.Hex()
+	if err := s.repos.Menus.Create(ctx, &menu); err != nil {
+		s.t.Fatalf("creating menu: %v", err)
+	}
+
+	foodName, price := name+" special", 9.5
+	food := models.Food{ID: primitive.NewObjectID(), Name: &foodName, Price: &price, MenuID: &menu.MenuID, CreatedAt: now, UpdatedAt: now}
+	food.FoodID = food.ID.Hex()
+	if err := s.repos.Foods.Create(ctx, &food); err != nil {
+		s.t.Fatalf("creating food: %v", err)
+	}
+	return menu.MenuID, food.FoodID
+}
+
+func TestGuestMenuFoodsStayScopedToTheMenu(t *testing.T) {
+	s := newTestServer(t)
+	menuA, foodA := s.createMenuWithFood("Lunch")
+	menuB, _ := s.createMenuWithFood("Dinner")
+
+	res := s.do(request{method: http.MethodGet, path: "/api/v1/guest/menus/" + menuA + "/foods?menuId=" + menuB})
+	expectStatus(t, res, http.StatusOK)
+
+	var body foodListResponse
+	decode(t, res, &body)
+	if body.Total != 0 || len(body.Items) != 0 {
+		t.Fatalf("expected no foods when filtering menu A by menu B, got %s", res.Body.String())
+	}
+
+	res = s.do(request{method: http.MethodGet, path: "/api/v1/guest/menus/" + menuA + "/foods?menuId=" + menuA})
+	expectStatus(t, res, http.StatusOK)
+
+	decode(t, res, &body)
+	if body.Total != 1 || body.Items[0].FoodID != foodA {
+		t.Fatalf("expected only menu A's food, got %s", res.Body.String())
+	}
+}
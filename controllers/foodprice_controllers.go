@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+type FoodPriceController struct {
+	foods  repositories.FoodRepository
+	prices repositories.FoodPriceRepository
+}
+
+func NewFoodPriceController(foods repositories.FoodRepository, prices repositories.FoodPriceRepository) *FoodPriceController {
+	return &FoodPriceController{foods: foods, prices: prices}
+}
+
+func (ctrl *FoodPriceController) CreateFoodPrice() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		foodID := c.Param("foodId")
+		if foodID == "" {
+			apierrors.Write(c, http.StatusBadRequest, "foodId parameter is required")
+			return
+		}
+
+		var foodPrice models.FoodPrice
+		if err := c.ShouldBindJSON(&foodPrice); err != nil {
+			apierrors.InvalidBody(c, err)
+			return
+		}
+
+		if err := validate.Struct(foodPrice); err != nil {
+			apierrors.Validation(c, err)
+			return
+		}
+
+		food, err := ctrl.foods.FindByID(ctx, foodID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Food item not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Error occurred while fetching the food item", err)
+			return
+		}
+
+		now := time.Now().UTC()
+		effectiveFrom := now
+		if foodPrice.EffectiveFrom != nil {
+			effectiveFrom = foodPrice.EffectiveFrom.UTC()
+		}
+
+		if effectiveFrom.Before(now.Add(-time.Minute)) {
+			apierrors.Write(c, http.StatusBadRequest, "effectiveFrom cannot be in the past")
+			return
+		}
+
+		createdPrice, err := helper.RecordFoodPrice(ctx, ctrl.prices, foodID, *foodPrice.Price, effectiveFrom)
+		if err != nil {
+			apierrors.Internal(c, "Failed to record food price", err)
+			return
+		}
+
+		if !effectiveFrom.After(now) {
+			food.Price = createdPrice.Price
+			food.UpdatedAt = now
+			if err := ctrl.foods.Update(ctx, food); err != nil {
+				apierrors.Internal(c, "Failed to update food item price", err)
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Food price recorded successfully", "foodPrice": createdPrice})
+	}
+}
+
+func (ctrl *FoodPriceController) GetFoodPrices() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		foodID := c.Param("foodId")
+		if foodID == "" {
+			apierrors.Write(c, http.StatusBadRequest, "foodId parameter is required")
+			return
+		}
+
+		at := time.Now().UTC()
+		if rawAt := c.Query("at"); rawAt != "" {
+			parsedAt, err := time.Parse(time.RFC3339, rawAt)
+			if err != nil {
+				apierrors.Write(c, http.StatusBadRequest, "at must be an RFC3339 timestamp")
+				return
+			}
+			at = parsedAt.UTC()
+		}
+
+		food, err := ctrl.foods.FindByID(ctx, foodID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Food item not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Error occurred while fetching the food item", err)
+			return
+		}
+
+		prices, err := ctrl.prices.ListByFood(ctx, foodID)
+		if err != nil {
+			apierrors.Internal(c, "Failed to retrieve food prices", err)
+			return
+		}
+
+		effectivePrice, err := helper.GetEffectivePrice(ctx, ctrl.prices, *food, at)
+		if err != nil {
+			apierrors.Internal(c, "Failed to resolve effective price", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"at":             at,
+			"effectivePrice": effectivePrice,
+			"totalCount":     len(prices),
+			"prices":         prices,
+		})
+	}
+}
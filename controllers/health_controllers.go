@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/datarohit/go-restaurant-management-backend-project/database"
+	"github.com/datarohit/go-restaurant-management-backend-project/health"
 	"github.com/gin-gonic/gin"
 )
 
@@ -16,20 +17,26 @@ func GetRouterHealth(c *gin.Context) {
 }
 
 func GetDatabaseHealth(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	dbClient := database.Client
-	if dbClient == nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{
-			"status": "Database client uninitialized",
-		})
+	state, stateErr := database.Status()
+	if state != database.StateConnected {
+		response := gin.H{
+			"status": "Database is not ready",
+			"state":  state,
+		}
+		if stateErr != nil {
+			response["error"] = stateErr.Error()
+		}
+		c.JSON(http.StatusServiceUnavailable, response)
 		return
 	}
 
-	if err := dbClient.Ping(ctx, nil); err != nil {
+	if err := database.Ping(ctx); err != nil {
 		c.JSON(http.StatusServiceUnavailable, gin.H{
 			"status": "Database unreachable",
+			"state":  state,
 			"error":  err.Error(),
 		})
 		return
@@ -37,5 +44,35 @@ func GetDatabaseHealth(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"status": "Database is healthy",
+		"state":  state,
 	})
 }
+
+type HealthController struct {
+	registry *health.Registry
+}
+
+func NewHealthController(registry *health.Registry) *HealthController {
+	return &HealthController{registry: registry}
+}
+
+func (ctrl *HealthController) Live() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writeHealthReport(c, ctrl.registry.Live(c.Request.Context()))
+	}
+}
+
+func (ctrl *HealthController) Ready() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writeHealthReport(c, ctrl.registry.Ready(c.Request.Context()))
+	}
+}
+
+func writeHealthReport(c *gin.Context, report health.Report) {
+	status := http.StatusOK
+	if report.Status != health.StatusPass {
+		status = http.StatusServiceUnavailable
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(status, report)
+}
@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/datarohit/go-restaurant-management-backend-project/storage"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const imageURLPrefix = "/api/v1/images/"
+
+type ImageController struct {
+	foods         repositories.FoodRepository
+	images        storage.BlobStore
+	maxUploadSize int64
+}
+
+func NewImageController(foods repositories.FoodRepository, images storage.BlobStore, maxUploadSize int64) *ImageController {
+	return &ImageController{foods: foods, images: images, maxUploadSize: maxUploadSize}
+}
+
+func (ctrl *ImageController) UploadFoodImage() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		foodID := c.Param("foodId")
+		if foodID == "" {
+			apierrors.Write(c, http.StatusBadRequest, "foodId parameter is required")
+			return
+		}
+
+		food, err := ctrl.foods.FindByID(ctx, foodID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Food item not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Error occurred while fetching the food item", err)
+			return
+		}
+
+		maxSize := ctrl.maxUploadSize
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+(1<<20))
+
+		fileHeader, err := c.FormFile("image")
+		if err != nil {
+			apierrors.Write(c, http.StatusBadRequest, "Multipart field 'image' is required")
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			apierrors.Write(c, http.StatusBadRequest, "Failed to read uploaded image")
+			return
+		}
+		defer file.Close()
+
+		data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+		if err != nil {
+			apierrors.Write(c, http.StatusBadRequest, "Failed to read uploaded image")
+			return
+		}
+
+		processed, err := helper.ProcessImage(data, maxSize)
+		if err != nil {
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		imageID := primitive.NewObjectID().Hex()
+		originalKey := fmt.Sprintf("foods/%s/%s.%s", foodID, imageID, processed.Extension)
+		if err := ctrl.images.Save(ctx, originalKey, processed.ContentType, bytes.NewReader(processed.Original)); err != nil {
+			apierrors.Internal(c, "Failed to store image", err)
+			return
+		}
+
+		thumbnailContentType := helper.ThumbnailContentType(processed.ContentType)
+		thumbnailExtension := helper.AllowedImageTypes[thumbnailContentType]
+		thumbnails := make(map[string]string, len(processed.Thumbnails))
+		for name, thumbnail := range processed.Thumbnails {
+			key := fmt.Sprintf("foods/%s/%s_%s.%s", foodID, imageID, name, thumbnailExtension)
+			if err := ctrl.images.Save(ctx, key, thumbnailContentType, bytes.NewReader(thumbnail)); err != nil {
+				apierrors.Internal(c, "Failed to store image thumbnail", err)
+				return
+			}
+			thumbnails[name] = imageURLPrefix + key
+		}
+
+		previous := *food
+		foodImage := imageURLPrefix + originalKey
+		food.FoodImage = &foodImage
+		food.Thumbnails = thumbnails
+		food.UpdatedAt = time.Now().UTC()
+
+		if err := ctrl.foods.Update(ctx, food); err != nil {
+			apierrors.Internal(c, "Failed to update food item", err)
+			return
+		}
+
+		ctrl.deleteStoredImages(ctx, previous)
+
+		c.JSON(http.StatusOK, gin.H{"message": "Food image uploaded successfully", "food": food})
+	}
+}
+
+func (ctrl *ImageController) GetImage() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		key := strings.TrimPrefix(c.Param("key"), "/")
+		if key == "" {
+			apierrors.Write(c, http.StatusBadRequest, "Image key is required")
+			return
+		}
+
+		reader, info, err := ctrl.images.Open(ctx, key)
+		if errors.Is(err, storage.ErrInvalidKey) {
+			apierrors.Write(c, http.StatusBadRequest, "Invalid image key")
+			return
+		} else if errors.Is(err, storage.ErrBlobNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Image not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve image", err)
+			return
+		}
+		defer reader.Close()
+
+		c.DataFromReader(http.StatusOK, info.Size, info.ContentType, reader, map[string]string{
+			"Cache-Control": "public, max-age=31536000, immutable",
+		})
+	}
+}
+
+func (ctrl *ImageController) deleteStoredImages(ctx context.Context, food models.Food) {
+	var urls []string
+	if food.FoodImage != nil {
+		urls = append(urls, *food.FoodImage)
+	}
+	for _, url := range food.Thumbnails {
+		urls = append(urls, url)
+	}
+
+	for _, url := range urls {
+		if !strings.HasPrefix(url, imageURLPrefix) {
+			continue
+		}
+		_ = ctrl.images.Delete(ctx, strings.TrimPrefix(url, imageURLPrefix))
+	}
+}
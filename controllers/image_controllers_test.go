@@ -0,0 +1,37 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/controllers"
+	"github.com/datarohit/go-restaurant-management-backend-project/routes"
+	"github.com/datarohit/go-restaurant-management-backend-project/storage"
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetImage(t *testing.T) {
+	s := newTestServer(t)
+	images := storage.NewLocalBlobStore(t.TempDir())
+	if err := images.Save(context.Background(), "foods/abc/original.png", "image/png", strings.NewReader("png-bytes")); err != nil {
+		t.Fatalf("saving image: %v", err)
+	}
+
+	router := gin.New()
+	routes.ImageRoutes(router, controllers.NewImageController(s.repos.Foods, images, 1<<20))
+	server := &testServer{t: t, router: router, repos: s.repos}
+
+	res := server.do(request{method: http.MethodGet, path: "/api/v1/images/foods/abc/original.png"})
+	expectStatus(t, res, http.StatusOK)
+	if res.Body.String() != "png-bytes" || res.Header().Get("Content-Type") != "image/png" {
+		t.Fatalf("unexpected image response %q (%s)", res.Body.String(), res.Header().Get("Content-Type"))
+	}
+
+	res = server.do(request{method: http.MethodGet, path: "/api/v1/images/foods/abc/missing.png"})
+	expectStatus(t, res, http.StatusNotFound)
+
+	res = server.do(request{method: http.MethodGet, path: "/api/v1/images/foods/..%2F..%2Fsecret.png"})
+	expectStatus(t, res, http.StatusBadRequest)
+}
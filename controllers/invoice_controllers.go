@@ -2,46 +2,60 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
-	"github.com/datarohit/go-restaurant-management-backend-project/database"
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
 	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/metrics"
 	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type InvoiceViewFormat struct {
-	InvoiceID      string
-	PaymentMethod  string
-	OrderID        string
-	PaymentStatus  *string
-	TableNumber    interface{}
-	PaymentDueDate time.Time
-	OrderDetails   interface{}
+	InvoiceID         string
+	PaymentMethod     string
+	OrderID           string
+	PaymentStatus     *string
+	TableNumber       interface{}
+	PaymentDueDate    time.Time
+	OrderDetails      interface{}
+	Subtotal          float64
+	DiscountTotal     float64
+	Total             float64
+	AppliedPromotions []models.AppliedPromotion
 }
 
-var invoiceCollection *mongo.Collection = database.OpenCollection(database.Client, "invoice")
+type InvoiceController struct {
+	invoices   repositories.InvoiceRepository
+	orders     repositories.OrderRepository
+	tables     repositories.TableRepository
+	orderItems repositories.OrderItemRepository
+	evaluator  *helper.PromotionEvaluator
+}
+
+func NewInvoiceController(invoices repositories.InvoiceRepository, orders repositories.OrderRepository, tables repositories.TableRepository, orderItems repositories.OrderItemRepository, evaluator *helper.PromotionEvaluator) *InvoiceController {
+	return &InvoiceController{invoices: invoices, orders: orders, tables: tables, orderItems: orderItems, evaluator: evaluator}
+}
 
-func CreateInvoice() gin.HandlerFunc {
+func (ctrl *InvoiceController) CreateInvoice() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
 		var invoice models.Invoice
 
-		if err := c.BindJSON(&invoice); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+		if err := c.ShouldBindJSON(&invoice); err != nil {
+			apierrors.InvalidBody(c, err)
 			return
 		}
 
-		var order models.Order
-		err := orderCollection.FindOne(ctx, bson.M{"orderId": invoice.OrderID}).Decode(&order)
+		order, err := ctrl.orders.FindByID(ctx, invoice.OrderID)
 		if err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+			apierrors.Write(c, http.StatusNotFound, "Order not found")
 			return
 		}
 
@@ -50,6 +64,11 @@ func CreateInvoice() gin.HandlerFunc {
 			invoice.PaymentStatus = &status
 		}
 
+		if _, err := ctrl.evaluator.TotalInvoice(ctx, &invoice, *order); err != nil {
+			apierrors.Internal(c, "Failed to compute invoice totals", err)
+			return
+		}
+
 		now := time.Now().UTC()
 		invoice.PaymentDueDate = now.AddDate(0, 0, 1)
 		invoice.CreatedAt = now
@@ -58,20 +77,21 @@ func CreateInvoice() gin.HandlerFunc {
 		invoice.InvoiceID = invoice.ID.Hex()
 
 		if err := validate.Struct(invoice); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed: " + err.Error()})
+			apierrors.Validation(c, err)
 			return
 		}
 
-		_, err = invoiceCollection.InsertOne(ctx, invoice)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invoice"})
+		if err := ctrl.invoices.Create(ctx, &invoice); err != nil {
+			apierrors.Internal(c, "Failed to create invoice", err)
 			return
 		}
+		if *invoice.PaymentStatus == "PAID" {
+			metrics.InvoicePaid(invoice.Total)
+		}
 
-		var createdInvoice models.Invoice
-		err = invoiceCollection.FindOne(ctx, bson.M{"invoiceId": invoice.InvoiceID}).Decode(&createdInvoice)
+		createdInvoice, err := ctrl.invoices.FindByID(ctx, invoice.InvoiceID)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve created invoice"})
+			apierrors.Internal(c, "Failed to retrieve created invoice", err)
 			return
 		}
 
@@ -79,146 +99,299 @@ func CreateInvoice() gin.HandlerFunc {
 	}
 }
 
-func GetAllInvoices() gin.HandlerFunc {
+var invoiceListSpec = helper.ListSpec{
+	Filters: map[string]helper.FieldType{
+		"orderId":        helper.StringField,
+		"paymentMethod":  helper.StringField,
+		"paymentStatus":  helper.StringField,
+		"paymentDueDate": helper.TimeField,
+		"total":          helper.NumberField,
+		"createdAt":      helper.TimeField,
+		"updatedAt":      helper.TimeField,
+	},
+	Sorts:       []string{"paymentDueDate", "total", "createdAt", "updatedAt"},
+	DefaultSort: "-createdAt",
+}
+
+func (ctrl *InvoiceController) GetAllInvoices() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
-		cursor, err := invoiceCollection.Find(ctx, bson.M{})
+		ctx, err := helper.DeletedScope(ctx, c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve invoices"})
+			apierrors.Write(c, http.StatusForbidden, err.Error())
 			return
 		}
 
-		var allInvoices []models.Invoice
-		if err := cursor.All(ctx, &allInvoices); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to parse invoices"})
+		query, err := helper.ParseListQuery(c, invoiceListSpec)
+		if err != nil {
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"invoices": allInvoices, "totalCount": len(allInvoices)})
+		if helper.UsesCursor(c) {
+			cursor, err := helper.ParseCursor(c)
+			if err != nil {
+				apierrors.Write(c, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			invoices, page, err := ctrl.invoices.Scroll(ctx, query, cursor)
+			if err != nil {
+				apierrors.Internal(c, "Failed to retrieve invoices", err)
+				return
+			}
+
+			c.JSON(http.StatusOK, helper.NewCursorListResponse(c, invoices, page, query))
+			return
+		}
+
+		invoices, total, err := ctrl.invoices.List(ctx, query)
+		if err != nil {
+			apierrors.Internal(c, "Failed to retrieve invoices", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, helper.NewListResponse(c, invoices, total, query))
 	}
 }
 
-func GetInvoiceByID() gin.HandlerFunc {
+func (ctrl *InvoiceController) GetInvoiceByID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
+		ctx, err := helper.DeletedScope(ctx, c)
+		if err != nil {
+			apierrors.Write(c, http.StatusForbidden, err.Error())
+			return
+		}
+
 		invoiceID := c.Param("invoiceId")
 
-		var invoice models.Invoice
-		err := invoiceCollection.FindOne(ctx, bson.M{"invoiceId": invoiceID}).Decode(&invoice)
+		invoice, err := ctrl.invoices.FindByID(ctx, invoiceID)
 		if err != nil {
-			if err == mongo.ErrNoDocuments {
-				c.JSON(http.StatusNotFound, gin.H{"error": "Invoice not found"})
+			if errors.Is(err, repositories.ErrNotFound) {
+				apierrors.Write(c, http.StatusNotFound, "Invoice not found")
 			} else {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve invoice"})
+				apierrors.Internal(c, "Failed to retrieve invoice", err)
 			}
 			return
 		}
 
-		var order models.Order
-		err = orderCollection.FindOne(ctx, bson.M{"orderId": invoice.OrderID}).Decode(&order)
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		order, err := ctrl.orders.FindByID(ctx, invoice.OrderID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Order not found")
 			return
 		} else if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve order"})
+			apierrors.Internal(c, "Failed to retrieve order", err)
 			return
 		}
 
-		var table models.Table
-		err = tableCollection.FindOne(ctx, bson.M{"tableId": order.TableID}).Decode(&table)
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Table not found"})
+		table, err := ctrl.tables.FindByID(repositories.WithDeleted(ctx), helper.GetNonNilString(order.TableID, ""))
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Table not found")
 			return
 		} else if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve table"})
+			apierrors.Internal(c, "Failed to retrieve table", err)
 			return
 		}
 
-		cursor, err := orderItemCollection.Find(ctx, bson.M{"orderId": invoice.OrderID})
+		orderItems, err := ctrl.orderItems.ListByOrder(ctx, invoice.OrderID)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve order items"})
-			return
-		}
-		defer cursor.Close(ctx)
-
-		var orderItems []models.OrderItem
-		if err := cursor.All(ctx, &orderItems); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error while parsing order items for order"})
+			apierrors.Internal(c, "Failed to retrieve order items", err)
 			return
 		}
 
 		if len(orderItems) == 0 {
-			c.JSON(http.StatusNotFound, gin.H{"error": "No order items found for this invoice"})
+			apierrors.Write(c, http.StatusNotFound, "No order items found for this invoice")
 			return
 		}
 
 		invoiceView := InvoiceViewFormat{
-			OrderID:        invoice.OrderID,
-			PaymentDueDate: invoice.PaymentDueDate,
-			PaymentMethod:  helper.GetNonNilString(invoice.PaymentMethod, "null"),
-			InvoiceID:      invoice.InvoiceID,
-			PaymentStatus:  invoice.PaymentStatus,
-			TableNumber:    table.TableNumber,
-			OrderDetails:   orderItems,
+			OrderID:           invoice.OrderID,
+			PaymentDueDate:    invoice.PaymentDueDate,
+			PaymentMethod:     helper.GetNonNilString(invoice.PaymentMethod, "null"),
+			InvoiceID:         invoice.InvoiceID,
+			PaymentStatus:     invoice.PaymentStatus,
+			TableNumber:       table.TableNumber,
+			OrderDetails:      orderItems,
+			Subtotal:          invoice.Subtotal,
+			DiscountTotal:     invoice.DiscountTotal,
+			Total:             invoice.Total,
+			AppliedPromotions: invoice.AppliedPromotions,
 		}
 
+		helper.SetETag(c, invoice.Version)
 		c.JSON(http.StatusOK, gin.H{"invoice": invoiceView})
 	}
 }
 
-func UpdateInvoiceByID() gin.HandlerFunc {
+func (ctrl *InvoiceController) UpdateInvoiceByID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
 		invoiceID := c.Param("invoiceId")
 		var updateData models.Invoice
 
-		if err := c.BindJSON(&updateData); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+		if err := c.ShouldBindJSON(&updateData); err != nil {
+			apierrors.InvalidBody(c, err)
 			return
 		}
 
-		filter := bson.M{"invoiceId": invoiceID}
-		updateFields := bson.D{}
+		invoice, err := ctrl.invoices.FindByID(ctx, invoiceID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Invoice not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve invoice", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, invoice.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Invoice has been modified; fetch it again and retry")
+			return
+		}
+
+		wasPaid := invoice.PaymentStatus != nil && *invoice.PaymentStatus == "PAID"
+
+		if !wasPaid {
+			order, err := ctrl.orders.FindByID(ctx, invoice.OrderID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				apierrors.Write(c, http.StatusConflict, "The order of this invoice is deleted")
+				return
+			} else if err != nil {
+				apierrors.Internal(c, "Failed to retrieve order", err)
+				return
+			}
+
+			if _, err := ctrl.evaluator.TotalInvoice(ctx, invoice, *order); err != nil {
+				apierrors.Internal(c, "Failed to compute invoice totals", err)
+				return
+			}
+		}
 
 		if updateData.PaymentMethod != nil {
-			updateFields = append(updateFields, bson.E{Key: "paymentMethod", Value: updateData.PaymentMethod})
+			invoice.PaymentMethod = updateData.PaymentMethod
 		}
 
 		if updateData.PaymentStatus != nil {
-			updateFields = append(updateFields, bson.E{Key: "paymentStatus", Value: updateData.PaymentStatus})
+			invoice.PaymentStatus = updateData.PaymentStatus
 		} else {
 			defaultStatus := "PENDING"
-			updateFields = append(updateFields, bson.E{Key: "paymentStatus", Value: defaultStatus})
+			invoice.PaymentStatus = &defaultStatus
+		}
+
+		invoice.UpdatedAt = time.Now().UTC()
+
+		if err := ctrl.invoices.Update(ctx, invoice); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Invoice has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to update invoice", err)
+			return
 		}
+		if !wasPaid && *invoice.PaymentStatus == "PAID" {
+			metrics.InvoicePaid(invoice.Total)
+		}
+
+		helper.SetETag(c, invoice.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Invoice updated successfully", "invoice": invoice})
+	}
+}
 
-		updateFields = append(updateFields, bson.E{Key: "updatedAt", Value: time.Now().UTC()})
+func (ctrl *InvoiceController) DeleteInvoiceByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
 
-		update := bson.D{{Key: "$set", Value: updateFields}}
+		invoiceID := c.Param("invoiceId")
 
-		result, err := invoiceCollection.UpdateOne(ctx, filter, update)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update invoice: " + err.Error()})
+		invoice, err := ctrl.invoices.FindByID(ctx, invoiceID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Invoice not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve invoice", err)
 			return
 		}
 
-		if result.MatchedCount == 0 {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Invoice not found"})
+		if !helper.CheckIfMatch(c, invoice.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Invoice has been modified; fetch it again and retry")
 			return
 		}
 
-		var updatedInvoice models.Invoice
-		err = invoiceCollection.FindOne(ctx, filter).Decode(&updatedInvoice)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve updated invoice: " + err.Error()})
+		deletedAt, deletedBy := helper.DeletionStamp(c)
+
+		invoice.DeletedAt = &deletedAt
+		invoice.DeletedBy = deletedBy
+		invoice.UpdatedAt = deletedAt
+
+		if err := ctrl.invoices.Update(ctx, invoice); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Invoice has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to delete invoice", err)
+			return
+		}
+
+		helper.SetETag(c, invoice.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Invoice deleted successfully", "invoice": invoice})
+	}
+}
+
+func (ctrl *InvoiceController) RestoreInvoiceByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		invoiceID := c.Param("invoiceId")
+
+		invoice, err := ctrl.invoices.FindByID(repositories.WithDeleted(ctx), invoiceID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Invoice not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve invoice", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, invoice.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Invoice has been modified; fetch it again and retry")
+			return
+		}
+
+		if invoice.DeletedAt == nil {
+			apierrors.Write(c, http.StatusConflict, "Invoice is not deleted")
+			return
+		}
+
+		if invoice.OrderID != "" {
+			_, err := ctrl.orders.FindByID(ctx, invoice.OrderID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				apierrors.Write(c, http.StatusConflict, "The order of this invoice is deleted; restore it first")
+				return
+			} else if err != nil {
+				apierrors.Internal(c, "Failed to retrieve order", err)
+				return
+			}
+		}
+
+		invoice.DeletedAt = nil
+		invoice.DeletedBy = nil
+		invoice.UpdatedAt = time.Now().UTC()
+
+		if err := ctrl.invoices.Update(ctx, invoice); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Invoice has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to restore invoice", err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"message": "Invoice updated successfully", "invoice": updatedInvoice})
+		helper.SetETag(c, invoice.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Invoice restored successfully", "invoice": invoice})
 	}
 }
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type LogLevelRequest struct {
+	Level string `json:"level" validate:"required,eq=debug|eq=info|eq=warn|eq=error"`
+}
+
+type LoggingController struct {
+	level zap.AtomicLevel
+}
+
+func NewLoggingController(level zap.AtomicLevel) *LoggingController {
+	return &LoggingController{level: level}
+}
+
+func (ctrl *LoggingController) GetLogLevel() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"level": ctrl.level.Level().String()})
+	}
+}
+
+func (ctrl *LoggingController) UpdateLogLevel() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request LogLevelRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			apierrors.InvalidBody(c, err)
+			return
+		}
+
+		if err := validate.Struct(request); err != nil {
+			apierrors.Validation(c, err)
+			return
+		}
+
+		level, err := zapcore.ParseLevel(request.Level)
+		if err != nil {
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		previous := ctrl.level.Level()
+		ctrl.level.SetLevel(level)
+		utils.LoggerFrom(c.Request.Context()).Warn("Log level changed",
+			zap.String("from", previous.String()),
+			zap.String("to", level.String()))
+
+		c.JSON(http.StatusOK, gin.H{"message": "Log level updated successfully", "level": level.String()})
+	}
+}
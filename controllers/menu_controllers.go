@@ -2,34 +2,41 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
-	"github.com/datarohit/go-restaurant-management-backend-project/database"
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
 	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
 	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-var menuCollection *mongo.Collection = database.OpenCollection(database.Client, "menu")
+type MenuController struct {
+	menus   repositories.MenuRepository
+	foods   repositories.FoodRepository
+	bundles repositories.BundleRepository
+}
+
+func NewMenuController(menus repositories.MenuRepository, foods repositories.FoodRepository, bundles repositories.BundleRepository) *MenuController {
+	return &MenuController{menus: menus, foods: foods, bundles: bundles}
+}
 
-func CreateMenu() gin.HandlerFunc {
+func (ctrl *MenuController) CreateMenu() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
 		var menu models.Menu
 		if err := c.ShouldBindJSON(&menu); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+			apierrors.InvalidBody(c, err)
 			return
 		}
 
 		if err := validate.Struct(menu); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			apierrors.Validation(c, err)
 			return
 		}
 
@@ -39,8 +46,8 @@ func CreateMenu() gin.HandlerFunc {
 		menu.ID = primitive.NewObjectID()
 		menu.MenuID = menu.ID.Hex()
 
-		if _, err := menuCollection.InsertOne(ctx, menu); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create menu item"})
+		if err := ctrl.menus.Create(ctx, &menu); err != nil {
+			apierrors.Internal(c, "Failed to create menu item", err)
 			return
 		}
 
@@ -48,120 +55,306 @@ func CreateMenu() gin.HandlerFunc {
 	}
 }
 
-func GetAllMenus() gin.HandlerFunc {
+var menuListSpec = helper.ListSpec{
+	Filters: map[string]helper.FieldType{
+		"name":      helper.StringField,
+		"category":  helper.StringField,
+		"startDate": helper.TimeField,
+		"endDate":   helper.TimeField,
+		"createdAt": helper.TimeField,
+		"updatedAt": helper.TimeField,
+	},
+	Sorts:       []string{"name", "category", "startDate", "endDate", "createdAt", "updatedAt"},
+	DefaultSort: "-createdAt",
+}
+
+func (ctrl *MenuController) GetAllMenus() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
-		totalCount, err := menuCollection.CountDocuments(ctx, bson.M{})
+		ctx, err := helper.DeletedScope(ctx, c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count menu items"})
+			apierrors.Write(c, http.StatusForbidden, err.Error())
 			return
 		}
 
-		cursor, err := menuCollection.Find(ctx, bson.M{})
+		query, err := helper.ParseListQuery(c, menuListSpec)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve menu items"})
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
 			return
 		}
-		defer cursor.Close(ctx)
 
-		var menus []models.Menu
-		if err := cursor.All(ctx, &menus); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error while parsing menu items"})
+		menus, total, err := ctrl.menus.List(ctx, query)
+		if err != nil {
+			apierrors.Internal(c, "Failed to retrieve menu items", err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"totalCount": totalCount,
-			"menus":      menus,
-		})
+		c.JSON(http.StatusOK, helper.NewListResponse(c, menus, total, query))
 	}
 }
 
-func GetMenuByID() gin.HandlerFunc {
+func (ctrl *MenuController) GetMenuByID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
+		ctx, err := helper.DeletedScope(ctx, c)
+		if err != nil {
+			apierrors.Write(c, http.StatusForbidden, err.Error())
+			return
+		}
+
 		menuId := c.Param("menuId")
 		if menuId == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "menu_id parameter is required"})
+			apierrors.Write(c, http.StatusBadRequest, "menu_id parameter is required")
 			return
 		}
 
-		var menu models.Menu
-		err := menuCollection.FindOne(ctx, bson.M{"menuId": menuId}).Decode(&menu)
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Menu not found"})
+		menu, err := ctrl.menus.FindByID(ctx, menuId)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Menu not found")
 			return
 		} else if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error occurred while fetching the menu"})
+			apierrors.Internal(c, "Error occurred while fetching the menu", err)
 			return
 		}
 
+		helper.SetETag(c, menu.Version)
 		c.JSON(http.StatusOK, gin.H{"menu": menu})
 	}
 }
 
-func UpdateMenuByID() gin.HandlerFunc {
+func (ctrl *MenuController) UpdateMenuByID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
 		menuId := c.Param("menuId")
 		if menuId == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "menuId parameter is required"})
+			apierrors.Write(c, http.StatusBadRequest, "menuId parameter is required")
 			return
 		}
 
 		var menu models.Menu
-		if err := c.BindJSON(&menu); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		if err := c.ShouldBindJSON(&menu); err != nil {
+			apierrors.InvalidBody(c, err)
 			return
 		}
 
-		var updateObj primitive.D
+		existing, err := ctrl.menus.FindByID(ctx, menuId)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Menu not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Error occurred while fetching the menu", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, existing.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Menu has been modified; fetch it again and retry")
+			return
+		}
+
+		updated := false
 
 		if menu.StartDate != nil && menu.EndDate != nil {
 			if !helper.InTimeSpan(*menu.StartDate, *menu.EndDate, time.Now().UTC()) {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Start date must be before end date"})
+				apierrors.Write(c, http.StatusBadRequest, "Start date must be before end date")
 				return
 			}
-			updateObj = append(updateObj, bson.E{Key: "startDate", Value: menu.StartDate}, bson.E{Key: "endDate", Value: menu.EndDate})
+			existing.StartDate = menu.StartDate
+			existing.EndDate = menu.EndDate
+			updated = true
 		}
 
 		if menu.Name != "" {
-			updateObj = append(updateObj, bson.E{Key: "name", Value: menu.Name})
+			existing.Name = menu.Name
+			updated = true
 		}
 
 		if menu.Category != "" {
-			updateObj = append(updateObj, bson.E{Key: "category", Value: menu.Category})
+			existing.Category = menu.Category
+			updated = true
+		}
+
+		if !updated {
+			apierrors.Write(c, http.StatusBadRequest, "No fields to update")
+			return
+		}
+
+		existing.UpdatedAt = time.Now().UTC()
+
+		if err := ctrl.menus.Update(ctx, existing); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Menu has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to update menu", err)
+			return
+		}
+
+		helper.SetETag(c, existing.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Menu updated successfully", "menu": existing})
+	}
+}
+
+func (ctrl *MenuController) DeleteMenuByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		menuId := c.Param("menuId")
+
+		menu, err := ctrl.menus.FindByID(ctx, menuId)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Menu not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve menu", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, menu.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Menu has been modified; fetch it again and retry")
+			return
+		}
+
+		foods, _, err := ctrl.foods.List(ctx, repositories.FoodFilter{MenuID: menuId}, repositories.Query{})
+		if err != nil {
+			apierrors.Internal(c, "Failed to check the foods of this menu", err)
+			return
+		}
+
+		bundles, err := ctrl.bundles.ListByMenu(ctx, menuId)
+		if err != nil {
+			apierrors.Internal(c, "Failed to check the bundles of this menu", err)
+			return
+		}
+
+		if (len(foods) > 0 || len(bundles) > 0) && !helper.IsCascade(c) {
+			apierrors.New(http.StatusConflict, "Menu still has foods or bundles; pass cascade=true to delete them as well").
+				With("foodCount", len(foods)).
+				With("bundleCount", len(bundles)).
+				Send(c)
+			return
+		}
+
+		deletedAt, deletedBy := helper.DeletionStamp(c)
+
+		for i := range foods {
+			foods[i].DeletedAt = &deletedAt
+			foods[i].DeletedBy = deletedBy
+			foods[i].UpdatedAt = deletedAt
+			if err := ctrl.foods.Update(ctx, &foods[i]); err != nil {
+				apierrors.Internal(c, "Failed to delete the foods of this menu", err)
+				return
+			}
+		}
+
+		for i := range bundles {
+			bundles[i].DeletedAt = &deletedAt
+			bundles[i].DeletedBy = deletedBy
+			bundles[i].UpdatedAt = deletedAt
+			if err := ctrl.bundles.Update(ctx, &bundles[i]); err != nil {
+				apierrors.Internal(c, "Failed to delete the bundles of this menu", err)
+				return
+			}
+		}
+
+		menu.DeletedAt = &deletedAt
+		menu.DeletedBy = deletedBy
+		menu.UpdatedAt = deletedAt
+
+		if err := ctrl.menus.Update(ctx, menu); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Menu has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to delete menu", err)
+			return
+		}
+
+		helper.SetETag(c, menu.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Menu deleted successfully", "menu": menu})
+	}
+}
+
+func (ctrl *MenuController) RestoreMenuByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		menuId := c.Param("menuId")
+
+		menu, err := ctrl.menus.FindByID(repositories.WithDeleted(ctx), menuId)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Menu not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve menu", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, menu.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Menu has been modified; fetch it again and retry")
+			return
 		}
 
-		if len(updateObj) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "No fields to update"})
+		if menu.DeletedAt == nil {
+			apierrors.Write(c, http.StatusConflict, "Menu is not deleted")
 			return
 		}
 
+		deletedAt := *menu.DeletedAt
+		menu.DeletedAt = nil
+		menu.DeletedBy = nil
 		menu.UpdatedAt = time.Now().UTC()
-		updateObj = append(updateObj, bson.E{Key: "updated_at", Value: menu.UpdatedAt})
 
-		filter := bson.M{"menuId": menuId}
-		opts := options.Update().SetUpsert(true)
+		if err := ctrl.menus.Update(ctx, menu); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Menu has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to restore menu", err)
+			return
+		}
 
-		_, err := menuCollection.UpdateOne(ctx, filter, bson.D{{Key: "$set", Value: updateObj}}, opts)
+		foods, _, err := ctrl.foods.List(repositories.WithDeleted(ctx), repositories.FoodFilter{MenuID: menuId}, repositories.Query{})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update menu"})
+			apierrors.Internal(c, "Failed to retrieve the foods of this menu", err)
 			return
 		}
+		for i := range foods {
+			if !helper.DeletedWith(foods[i].DeletedAt, deletedAt) {
+				continue
+			}
+			foods[i].DeletedAt = nil
+			foods[i].DeletedBy = nil
+			foods[i].UpdatedAt = menu.UpdatedAt
+			if err := ctrl.foods.Update(ctx, &foods[i]); err != nil {
+				apierrors.Internal(c, "Failed to restore the foods of this menu", err)
+				return
+			}
+		}
 
-		var updatedMenu models.Menu
-		if err := menuCollection.FindOne(ctx, filter).Decode(&updatedMenu); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve updated menu"})
+		bundles, err := ctrl.bundles.ListByMenu(repositories.WithDeleted(ctx), menuId)
+		if err != nil {
+			apierrors.Internal(c, "Failed to retrieve the bundles of this menu", err)
 			return
 		}
+		for i := range bundles {
+			if !helper.DeletedWith(bundles[i].DeletedAt, deletedAt) {
+				continue
+			}
+			bundles[i].DeletedAt = nil
+			bundles[i].DeletedBy = nil
+			bundles[i].UpdatedAt = menu.UpdatedAt
+			if err := ctrl.bundles.Update(ctx, &bundles[i]); err != nil {
+				apierrors.Internal(c, "Failed to restore the bundles of this menu", err)
+				return
+			}
+		}
 
-		c.JSON(http.StatusOK, gin.H{"message": "Menu updated successfully", "menu": updatedMenu})
+		helper.SetETag(c, menu.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Menu restored successfully", "menu": menu})
 	}
 }
@@ -0,0 +1,91 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/gin-gonic/gin"
+)
+
+type menuResponse struct {
+	Menu models.Menu `json:"menu"`
+}
+
+func TestMenuCRUD(t *testing.T) {
+	s := newTestServer(t)
+	token := s.adminToken()
+
+	res := s.do(request{method: http.MethodPost, path: "/api/v1/menus/", token: token, body: gin.H{"name": "Lunch"}})
+	expectStatus(t, res, http.StatusBadRequest)
+	expectCode(t, res, "validation_failed")
+
+	res = s.do(request{method: http.MethodPost, path: "/api/v1/menus/", token: token, body: gin.H{"name": "Lunch", "category": "Mains"}})
+	expectStatus(t, res, http.StatusOK)
+
+	var created menuResponse
+	decode(t, res, &created)
+	path := "/api/v1/menus/" + created.Menu.MenuID
+
+	res = s.do(request{method: http.MethodGet, path: path, token: token})
+	expectStatus(t, res, http.StatusOK)
+	if etag := res.Header().Get("ETag"); etag != `"1"` {
+		t.Fatalf("expected ETag \"1\", got %q", etag)
+	}
+
+	res = s.do(request{method: http.MethodPatch, path: path, token: token, body: gin.H{"name": "Dinner"}})
+	expectStatus(t, res, http.StatusOK)
+
+	var updated menuResponse
+	decode(t, res, &updated)
+	if updated.Menu.Name != "Dinner" || updated.Menu.Version != 2 {
+		t.Fatalf("expected the renamed menu at version 2, got %+v", updated.Menu)
+	}
+
+	res = s.do(request{method: http.MethodDelete, path: path, token: token})
+	expectStatus(t, res, http.StatusOK)
+
+	res = s.do(request{method: http.MethodGet, path: path, token: token})
+	expectStatus(t, res, http.StatusNotFound)
+
+	res = s.do(request{method: http.MethodGet, path: path + "?includeDeleted=true", token: token})
+	expectStatus(t, res, http.StatusOK)
+
+	res = s.do(request{method: http.MethodPost, path: path + "/restore", token: token})
+	expectStatus(t, res, http.StatusOK)
+
+	res = s.do(request{method: http.MethodGet, path: path, token: token})
+	expectStatus(t, res, http.StatusOK)
+}
+
+func TestMenuUpdateWithStaleETag(t *testing.T) {
+	s := newTestServer(t)
+	token := s.adminToken()
+
+	res := s.do(request{method: http.MethodPost, path: "/api/v1/menus/", token: token, body: gin.H{"name": "Lunch", "category": "Mains"}})
+	expectStatus(t, res, http.StatusOK)
+
+	var created menuResponse
+	decode(t, res, &created)
+	path := "/api/v1/menus/" + created.Menu.MenuID
+
+	res = s.do(request{method: http.MethodPatch, path: path, token: token, body: gin.H{"name": "Brunch"},
+		headers: map[string]string{"If-Match": `"1"`}})
+	expectStatus(t, res, http.StatusOK)
+
+	res = s.do(request{method: http.MethodPatch, path: path, token: token, body: gin.H{"name": "Dinner"},
+		headers: map[string]string{"If-Match": `"1"`}})
+	expectStatus(t, res, http.StatusPreconditionFailed)
+
+	res = s.do(request{method: http.MethodDelete, path: path, token: token, headers: map[string]string{"If-Match": `"1"`}})
+	expectStatus(t, res, http.StatusPreconditionFailed)
+
+	res = s.do(request{method: http.MethodGet, path: path, token: token})
+	expectStatus(t, res, http.StatusOK)
+
+	var current menuResponse
+	decode(t, res, &current)
+	if current.Menu.Name != "Brunch" {
+		t.Fatalf("expected the first update to win, got %q", current.Menu.Name)
+	}
+}
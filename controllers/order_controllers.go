@@ -2,45 +2,65 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
-	"github.com/datarohit/go-restaurant-management-backend-project/database"
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/metrics"
 	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-var orderCollection *mongo.Collection = database.OpenCollection(database.Client, "order")
+type KitchenTicketLine struct {
+	OrderItemID string   `json:"orderItemId"`
+	FoodID      string   `json:"foodId"`
+	FoodName    string   `json:"foodName"`
+	Quantity    string   `json:"quantity"`
+	Allergens   []string `json:"allergens"`
+	BundleID    string   `json:"bundleId,omitempty"`
+	Component   string   `json:"component,omitempty"`
+}
+
+type OrderController struct {
+	orders     repositories.OrderRepository
+	tables     repositories.TableRepository
+	orderItems repositories.OrderItemRepository
+	foods      repositories.FoodRepository
+	invoices   repositories.InvoiceRepository
+}
+
+func NewOrderController(orders repositories.OrderRepository, tables repositories.TableRepository, orderItems repositories.OrderItemRepository, foods repositories.FoodRepository, invoices repositories.InvoiceRepository) *OrderController {
+	return &OrderController{orders: orders, tables: tables, orderItems: orderItems, foods: foods, invoices: invoices}
+}
 
-func CreateOrder() gin.HandlerFunc {
+func (ctrl *OrderController) CreateOrder() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
 		var order models.Order
-		if err := c.BindJSON(&order); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		if err := c.ShouldBindJSON(&order); err != nil {
+			apierrors.InvalidBody(c, err)
 			return
 		}
 
 		if err := validate.Struct(order); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			apierrors.Validation(c, err)
 			return
 		}
 
 		if *(order.TableID) != "" {
-			var table models.Table
-			err := tableCollection.FindOne(ctx, bson.M{"tableId": order.TableID}).Decode(&table)
-			if err == mongo.ErrNoDocuments {
-				c.JSON(http.StatusNotFound, gin.H{"error": "Table not found"})
+			_, err := ctrl.tables.FindByID(ctx, *order.TableID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				apierrors.Write(c, http.StatusNotFound, "Table not found")
 				return
 			} else if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify table"})
+				apierrors.Internal(c, "Failed to verify table", err)
 				return
 			}
 		}
@@ -50,118 +70,392 @@ func CreateOrder() gin.HandlerFunc {
 		order.ID = primitive.NewObjectID()
 		order.OrderID = order.ID.Hex()
 
-		_, err := orderCollection.InsertOne(ctx, order)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create order"})
+		if err := ctrl.orders.Create(ctx, &order); err != nil {
+			apierrors.Internal(c, "Failed to create order", err)
 			return
 		}
+		metrics.OrderPlaced()
 
 		c.JSON(http.StatusOK, gin.H{"message": "Order created successfully", "order": order})
 	}
 }
 
-func GetAllOrders() gin.HandlerFunc {
+var orderListSpec = helper.ListSpec{
+	Filters: map[string]helper.FieldType{
+		"tableId":   helper.StringField,
+		"orderDate": helper.TimeField,
+		"createdAt": helper.TimeField,
+		"updatedAt": helper.TimeField,
+	},
+	Sorts:       []string{"orderDate", "createdAt", "updatedAt"},
+	DefaultSort: "-createdAt",
+}
+
+func (ctrl *OrderController) GetAllOrders() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
-		cursor, err := orderCollection.Find(ctx, bson.M{})
+		ctx, err := helper.DeletedScope(ctx, c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve orders"})
+			apierrors.Write(c, http.StatusForbidden, err.Error())
+			return
+		}
+
+		query, err := helper.ParseListQuery(c, orderListSpec)
+		if err != nil {
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if helper.UsesCursor(c) {
+			cursor, err := helper.ParseCursor(c)
+			if err != nil {
+				apierrors.Write(c, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			orders, page, err := ctrl.orders.Scroll(ctx, query, cursor)
+			if err != nil {
+				apierrors.Internal(c, "Failed to retrieve orders", err)
+				return
+			}
+
+			c.JSON(http.StatusOK, helper.NewCursorListResponse(c, orders, page, query))
 			return
 		}
-		defer cursor.Close(ctx)
 
-		var orders []models.Order
-		if err := cursor.All(ctx, &orders); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error while parsing orders"})
+		orders, total, err := ctrl.orders.List(ctx, query)
+		if err != nil {
+			apierrors.Internal(c, "Failed to retrieve orders", err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"totalCount": len(orders), "orders": orders})
+		c.JSON(http.StatusOK, helper.NewListResponse(c, orders, total, query))
 	}
 }
 
-func GetOrderByID() gin.HandlerFunc {
+func (ctrl *OrderController) GetOrderByID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
+		ctx, err := helper.DeletedScope(ctx, c)
+		if err != nil {
+			apierrors.Write(c, http.StatusForbidden, err.Error())
+			return
+		}
+
 		orderID := c.Param("orderId")
 		if orderID == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Order ID is required"})
+			apierrors.Write(c, http.StatusBadRequest, "Order ID is required")
 			return
 		}
 
-		var order models.Order
-		err := orderCollection.FindOne(ctx, bson.M{"orderId": orderID}).Decode(&order)
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		order, err := ctrl.orders.FindByID(ctx, orderID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Order not found")
 			return
 		} else if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve order"})
+			apierrors.Internal(c, "Failed to retrieve order", err)
 			return
 		}
 
+		helper.SetETag(c, order.Version)
 		c.JSON(http.StatusOK, gin.H{"order": order})
 	}
 }
 
-func UpdateOrderByID() gin.HandlerFunc {
+func (ctrl *OrderController) UpdateOrderByID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
 		orderID := c.Param("orderId")
 		if orderID == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Order ID is required"})
+			apierrors.Write(c, http.StatusBadRequest, "Order ID is required")
 			return
 		}
 
 		var order models.Order
-		if err := c.BindJSON(&order); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		if err := c.ShouldBindJSON(&order); err != nil {
+			apierrors.InvalidBody(c, err)
 			return
 		}
 
-		updateObj := bson.D{}
-		if *(order.TableID) != "" {
-			var table models.Table
-			err := tableCollection.FindOne(ctx, bson.M{"tableId": order.TableID}).Decode(&table)
-			if err == mongo.ErrNoDocuments {
-				c.JSON(http.StatusNotFound, gin.H{"error": "Table not found"})
+		existing, err := ctrl.orders.FindByID(ctx, orderID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Order not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve order", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, existing.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Order has been modified; fetch it again and retry")
+			return
+		}
+
+		if order.TableID == nil || *order.TableID == "" {
+			apierrors.Write(c, http.StatusBadRequest, "No fields to update")
+			return
+		}
+
+		_, err = ctrl.tables.FindByID(ctx, *order.TableID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Table not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to verify table", err)
+			return
+		}
+
+		existing.TableID = order.TableID
+		existing.UpdatedAt = time.Now().UTC()
+
+		if err := ctrl.orders.Update(ctx, existing); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Order has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to update order", err)
+			return
+		}
+
+		helper.SetETag(c, existing.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Order updated successfully", "order": existing})
+	}
+}
+
+func (ctrl *OrderController) GetOrderKitchenTicket() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		orderID := c.Param("orderId")
+
+		order, err := ctrl.orders.FindByID(ctx, orderID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Order not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve order", err)
+			return
+		}
+
+		orderItems, err := ctrl.orderItems.ListByOrder(ctx, orderID)
+		if err != nil {
+			apierrors.Internal(c, "Failed to retrieve order items", err)
+			return
+		}
+
+		var foodIDs []string
+		for _, item := range orderItems {
+			if item.FoodID != nil {
+				foodIDs = append(foodIDs, *item.FoodID)
+			}
+		}
+
+		foods, err := ctrl.foods.FindByIDs(repositories.WithDeleted(ctx), foodIDs)
+		if err != nil {
+			apierrors.Internal(c, "Failed to retrieve food items", err)
+			return
+		}
+
+		foodNames := make(map[string]string, len(foods))
+		for _, food := range foods {
+			foodNames[food.FoodID] = helper.GetNonNilString(food.Name, "")
+		}
+
+		lines := []KitchenTicketLine{}
+		for _, item := range orderItems {
+			quantity := helper.GetNonNilString(item.Quantity, "")
+
+			if item.BundleID != nil {
+				for _, component := range item.Components {
+					lines = append(lines, KitchenTicketLine{
+						OrderItemID: item.OrderItemID,
+						FoodID:      component.FoodID,
+						FoodName:    component.FoodName,
+						Quantity:    quantity,
+						Allergens:   component.Allergens,
+						BundleID:    *item.BundleID,
+						Component:   component.Name,
+					})
+				}
+				continue
+			}
+
+			foodID := helper.GetNonNilString(item.FoodID, "")
+			lines = append(lines, KitchenTicketLine{
+				OrderItemID: item.OrderItemID,
+				FoodID:      foodID,
+				FoodName:    foodNames[foodID],
+				Quantity:    quantity,
+				Allergens:   item.Allergens,
+			})
+		}
+
+		c.JSON(http.StatusOK, gin.H{"order": order, "totalCount": len(lines), "lines": lines})
+	}
+}
+
+func (ctrl *OrderController) DeleteOrderByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		orderID := c.Param("orderId")
+
+		order, err := ctrl.orders.FindByID(ctx, orderID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Order not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve order", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, order.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Order has been modified; fetch it again and retry")
+			return
+		}
+
+		orderItems, err := ctrl.orderItems.ListByOrder(ctx, orderID)
+		if err != nil {
+			apierrors.Internal(c, "Failed to check the order items of this order", err)
+			return
+		}
+
+		invoices, err := ctrl.invoices.ListByOrder(ctx, orderID)
+		if err != nil {
+			apierrors.Internal(c, "Failed to check the invoices of this order", err)
+			return
+		}
+
+		if (len(orderItems) > 0 || len(invoices) > 0) && !helper.IsCascade(c) {
+			apierrors.New(http.StatusConflict, "Order still has order items or invoices; pass cascade=true to delete them as well").
+				With("orderItemCount", len(orderItems)).
+				With("invoiceCount", len(invoices)).
+				Send(c)
+			return
+		}
+
+		deletedAt, deletedBy := helper.DeletionStamp(c)
+
+		for i := range orderItems {
+			orderItems[i].DeletedAt = &deletedAt
+			orderItems[i].DeletedBy = deletedBy
+			orderItems[i].UpdatedAt = deletedAt
+			if err := ctrl.orderItems.Update(ctx, &orderItems[i]); err != nil {
+				apierrors.Internal(c, "Failed to delete the order items of this order", err)
 				return
-			} else if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify table"})
+			}
+		}
+
+		for i := range invoices {
+			invoices[i].DeletedAt = &deletedAt
+			invoices[i].DeletedBy = deletedBy
+			invoices[i].UpdatedAt = deletedAt
+			if err := ctrl.invoices.Update(ctx, &invoices[i]); err != nil {
+				apierrors.Internal(c, "Failed to delete the invoices of this order", err)
 				return
 			}
-			updateObj = append(updateObj, bson.E{Key: "tableId", Value: order.TableID})
 		}
 
-		order.UpdatedAt = time.Now().UTC()
-		updateObj = append(updateObj, bson.E{Key: "updatedAt", Value: order.UpdatedAt})
+		order.DeletedAt = &deletedAt
+		order.DeletedBy = deletedBy
+		order.UpdatedAt = deletedAt
 
-		if len(updateObj) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "No fields to update"})
+		if err := ctrl.orders.Update(ctx, order); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Order has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to delete order", err)
 			return
 		}
 
-		filter := bson.M{"orderId": orderID}
-		opts := options.Update().SetUpsert(false)
+		helper.SetETag(c, order.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Order deleted successfully", "order": order})
+	}
+}
+
+func (ctrl *OrderController) RestoreOrderByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		orderID := c.Param("orderId")
+
+		order, err := ctrl.orders.FindByID(repositories.WithDeleted(ctx), orderID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Order not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve order", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, order.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Order has been modified; fetch it again and retry")
+			return
+		}
 
-		_, err := orderCollection.UpdateOne(ctx, filter, bson.D{{Key: "$set", Value: updateObj}}, opts)
+		if order.DeletedAt == nil {
+			apierrors.Write(c, http.StatusConflict, "Order is not deleted")
+			return
+		}
+
+		deletedAt := *order.DeletedAt
+		order.DeletedAt = nil
+		order.DeletedBy = nil
+		order.UpdatedAt = time.Now().UTC()
+
+		if err := ctrl.orders.Update(ctx, order); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Order has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to restore order", err)
+			return
+		}
+
+		orderItems, err := ctrl.orderItems.ListByOrder(repositories.WithDeleted(ctx), orderID)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update order"})
+			apierrors.Internal(c, "Failed to retrieve the order items of this order", err)
 			return
 		}
+		for i := range orderItems {
+			if !helper.DeletedWith(orderItems[i].DeletedAt, deletedAt) {
+				continue
+			}
+			orderItems[i].DeletedAt = nil
+			orderItems[i].DeletedBy = nil
+			orderItems[i].UpdatedAt = order.UpdatedAt
+			if err := ctrl.orderItems.Update(ctx, &orderItems[i]); err != nil {
+				apierrors.Internal(c, "Failed to restore the order items of this order", err)
+				return
+			}
+		}
 
-		var updatedOrder models.Order
-		err = orderCollection.FindOne(ctx, filter).Decode(&updatedOrder)
+		invoices, err := ctrl.invoices.ListByOrder(repositories.WithDeleted(ctx), orderID)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve updated order"})
+			apierrors.Internal(c, "Failed to retrieve the invoices of this order", err)
 			return
 		}
+		for i := range invoices {
+			if !helper.DeletedWith(invoices[i].DeletedAt, deletedAt) {
+				continue
+			}
+			invoices[i].DeletedAt = nil
+			invoices[i].DeletedBy = nil
+			invoices[i].UpdatedAt = order.UpdatedAt
+			if err := ctrl.invoices.Update(ctx, &invoices[i]); err != nil {
+				apierrors.Internal(c, "Failed to restore the invoices of this order", err)
+				return
+			}
+		}
 
-		c.JSON(http.StatusOK, gin.H{"message": "Order updated successfully", "order": updatedOrder})
+		helper.SetETag(c, order.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Order restored successfully", "order": order})
 	}
 }
@@ -0,0 +1,113 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/gin-gonic/gin"
+)
+
+type orderCursorResponse struct {
+	helper.CursorListResponse
+	Items []models.Order `json:"items"`
+}
+
+func (s *testServer) createOrders(token string, count int) []string {
+	s.t.Helper()
+
+	res := s.do(request{method: http.MethodPost, path: "/api/v1/tables/", token: token, body: gin.H{"tableNumber": 1, "numberOfGuests": 2}})
+	expectStatus(s.t, res, http.StatusOK)
+
+	var table struct {
+		Table models.Table `json:"table"`
+	}
+	decode(s.t, res, &table)
+
+	orderIDs := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		res := s.do(request{method: http.MethodPost, path: "/api/v1/orders/", token: token,
+			body: gin.H{"orderDate": time.Now().UTC(), "tableId": table.Table.TableID}})
+		expectStatus(s.t, res, http.StatusOK)
+
+		var order struct {
+			Order models.Order `json:"order"`
+		}
+		decode(s.t, res, &order)
+		orderIDs = append(orderIDs, order.Order.OrderID)
+	}
+	return orderIDs
+}
+
+func TestOrderCursorPagination(t *testing.T) {
+	s := newTestServer(t)
+	token := s.adminToken()
+	created := s.createOrders(token, 5)
+
+	var seen []string
+	path := "/api/v1/orders/?paginate=cursor&limit=2"
+	for page := 0; page < 5; page++ {
+		res := s.do(request{method: http.MethodGet, path: path, token: token})
+		expectStatus(t, res, http.StatusOK)
+
+		var body orderCursorResponse
+		decode(t, res, &body)
+		for _, order := range body.Items {
+			seen = append(seen, order.OrderID)
+		}
+
+		if body.NextCursor == nil {
+			break
+		}
+		path = "/api/v1/orders/?limit=2&cursor=" + url.QueryEscape(*body.NextCursor)
+	}
+
+	if len(seen) != len(created) {
+		t.Fatalf("expected %d orders across the pages, got %d", len(created), len(seen))
+	}
+	for i, orderID := range seen {
+		if want := created[len(created)-1-i]; orderID != want {
+			t.Fatalf("expected newest first: position %d is %s, want %s", i, orderID, want)
+		}
+	}
+}
+
+func TestOrderCursorModeIsOptIn(t *testing.T) {
+	s := newTestServer(t)
+	token := s.adminToken()
+	s.createOrders(token, 3)
+
+	res := s.do(request{method: http.MethodGet, path: "/api/v1/orders/?paginate=cursor&limit=1", token: token})
+	expectStatus(t, res, http.StatusOK)
+
+	var body orderCursorResponse
+	decode(t, res, &body)
+	if body.NextCursor == nil {
+		t.Fatalf("expected a next cursor: %s", res.Body.String())
+	}
+	cursor := url.QueryEscape(*body.NextCursor)
+
+	res = s.do(request{method: http.MethodGet, path: "/api/v1/orders/?page=2&cursor=" + cursor, token: token})
+	expectStatus(t, res, http.StatusBadRequest)
+
+	res = s.do(request{method: http.MethodGet, path: "/api/v1/orders/?sort=orderDate&cursor=" + cursor, token: token})
+	expectStatus(t, res, http.StatusBadRequest)
+
+	res = s.do(request{method: http.MethodGet, path: "/api/v1/orders/?paginate=cursor&page=1", token: token})
+	expectStatus(t, res, http.StatusBadRequest)
+
+	res = s.do(request{method: http.MethodGet, path: "/api/v1/orders/?cursor=garbage", token: token})
+	expectStatus(t, res, http.StatusBadRequest)
+
+	res = s.do(request{method: http.MethodGet, path: "/api/v1/orders/?limit=2", token: token})
+	expectStatus(t, res, http.StatusOK)
+
+	var paged helper.ListResponse
+	decode(t, res, &paged)
+	if paged.Total != 3 {
+		t.Fatalf("expected page mode to report a total of 3, got %s", res.Body.String())
+	}
+}
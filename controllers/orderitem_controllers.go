@@ -2,39 +2,54 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
-	"github.com/datarohit/go-restaurant-management-backend-project/database"
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
 	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
 	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
 )
 
 type OrderItemPack struct {
 	TableID    string `json:"tableId" binding:"required"`
 	OrderItems []struct {
-		FoodID   string `json:"foodId" binding:"required"`
-		Quantity string `json:"quantity" binding:"required,oneof=S M L"`
+		FoodID   string            `json:"foodId" binding:"required_without=BundleID"`
+		BundleID string            `json:"bundleId"`
+		Choices  map[string]string `json:"choices"`
+		Quantity string            `json:"quantity" binding:"required,oneof=S M L"`
 	} `json:"orderItems" binding:"required"`
 }
 
-var orderItemCollection *mongo.Collection = database.OpenCollection(database.Client, "orderItem")
+type OrderItemController struct {
+	orders     repositories.OrderRepository
+	orderItems repositories.OrderItemRepository
+	foods      repositories.FoodRepository
+	prices     repositories.FoodPriceRepository
+	bundles    repositories.BundleRepository
+	invoices   repositories.InvoiceRepository
+	evaluator  *helper.PromotionEvaluator
+}
+
+func NewOrderItemController(orders repositories.OrderRepository, orderItems repositories.OrderItemRepository, foods repositories.FoodRepository, prices repositories.FoodPriceRepository, bundles repositories.BundleRepository, invoices repositories.InvoiceRepository, evaluator *helper.PromotionEvaluator) *OrderItemController {
+	return &OrderItemController{orders: orders, orderItems: orderItems, foods: foods, prices: prices, bundles: bundles, invoices: invoices, evaluator: evaluator}
+}
 
-func CreateOrderItem() gin.HandlerFunc {
+func (ctrl *OrderItemController) CreateOrderItem() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
 		var orderItemPack OrderItemPack
 
-		if err := c.BindJSON(&orderItemPack); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		if err := c.ShouldBindJSON(&orderItemPack); err != nil {
+			apierrors.InvalidBody(c, err)
 			return
 		}
 
@@ -43,24 +58,67 @@ func CreateOrderItem() gin.HandlerFunc {
 			TableID:   &orderItemPack.TableID,
 		}
 
-		orderId, err := helper.OrderItemOrderCreator(order, ctx)
+		orderId, err := helper.OrderItemOrderCreator(ctx, ctrl.orders, order)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create order"})
+			apierrors.Internal(c, "Failed to create order", err)
 			return
 		}
 
-		var orderItemsToBeInserted []interface{}
 		var createdOrderItems []models.OrderItem
 
 		for _, item := range orderItemPack.OrderItems {
-			var food models.Food
-			err := foodCollection.FindOne(ctx, bson.M{"foodId": item.FoodID}).Decode(&food)
-			if err != nil {
-				c.JSON(http.StatusNotFound, gin.H{"error": "Food item not found", "foodId": item.FoodID})
+			if item.BundleID != "" {
+				bundle, err := ctrl.bundles.FindByID(ctx, item.BundleID)
+				if errors.Is(err, repositories.ErrNotFound) || (err == nil && bundle.Active != nil && !*bundle.Active) {
+					apierrors.New(http.StatusNotFound, "Bundle not found").With("bundleId", item.BundleID).Send(c)
+					return
+				} else if err != nil {
+					apierrors.Internal(c, "Error fetching bundle", err)
+					return
+				}
+
+				components, allergens, err := helper.ExpandBundle(ctx, ctrl.foods, *bundle, item.Choices)
+				if errors.Is(err, helper.ErrInvalidBundleSelection) {
+					apierrors.New(http.StatusBadRequest, err.Error()).With("bundleId", item.BundleID).Send(c)
+					return
+				} else if err != nil {
+					utils.LoggerFrom(ctx).Error("Failed to expand bundle", zap.String("bundleId", item.BundleID), zap.Error(err))
+					apierrors.New(http.StatusInternalServerError, "Failed to expand bundle").With("bundleId", item.BundleID).Send(c)
+					return
+				}
+
+				orderItem := models.OrderItem{
+					ID:          primitive.NewObjectID(),
+					Quantity:    &item.Quantity,
+					UnitPrice:   bundle.Price,
+					CreatedAt:   time.Now().UTC(),
+					UpdatedAt:   time.Now().UTC(),
+					BundleID:    &item.BundleID,
+					Components:  components,
+					Allergens:   allergens,
+					OrderItemID: primitive.NewObjectID().Hex(),
+					OrderID:     orderId,
+				}
+
+				createdOrderItems = append(createdOrderItems, orderItem)
+				continue
+			}
+
+			food, err := ctrl.foods.FindByID(ctx, item.FoodID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				apierrors.New(http.StatusNotFound, "Food item not found").With("foodId", item.FoodID).Send(c)
+				return
+			} else if err != nil {
+				apierrors.Internal(c, "Error fetching food item", err)
 				return
 			}
 
-			unitPrice := food.Price
+			unitPrice, err := helper.GetEffectivePrice(ctx, ctrl.prices, *food, order.OrderDate)
+			if err != nil {
+				utils.LoggerFrom(ctx).Error("Failed to resolve food price", zap.String("foodId", item.FoodID), zap.Error(err))
+				apierrors.New(http.StatusInternalServerError, "Failed to resolve food price").With("foodId", item.FoodID).Send(c)
+				return
+			}
 
 			orderItem := models.OrderItem{
 				ID:          primitive.NewObjectID(),
@@ -69,17 +127,16 @@ func CreateOrderItem() gin.HandlerFunc {
 				CreatedAt:   time.Now().UTC(),
 				UpdatedAt:   time.Now().UTC(),
 				FoodID:      &item.FoodID,
+				Allergens:   food.Allergens,
 				OrderItemID: primitive.NewObjectID().Hex(),
 				OrderID:     orderId,
 			}
 
-			orderItemsToBeInserted = append(orderItemsToBeInserted, orderItem)
 			createdOrderItems = append(createdOrderItems, orderItem)
 		}
 
-		_, err = orderItemCollection.InsertMany(ctx, orderItemsToBeInserted)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create order items"})
+		if err := ctrl.orderItems.CreateMany(ctx, createdOrderItems); err != nil {
+			apierrors.Internal(c, "Failed to create order items", err)
 			return
 		}
 
@@ -87,125 +144,329 @@ func CreateOrderItem() gin.HandlerFunc {
 	}
 }
 
-func GetAllOrderItems() gin.HandlerFunc {
+var orderItemListSpec = helper.ListSpec{
+	Filters: map[string]helper.FieldType{
+		"orderId":   helper.StringField,
+		"foodId":    helper.StringField,
+		"bundleId":  helper.StringField,
+		"quantity":  helper.StringField,
+		"unitPrice": helper.NumberField,
+		"createdAt": helper.TimeField,
+	},
+	Sorts:       []string{"unitPrice", "createdAt"},
+	DefaultSort: "-createdAt",
+}
+
+func (ctrl *OrderItemController) GetAllOrderItems() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
-		cursor, err := orderItemCollection.Find(ctx, bson.M{})
+		ctx, err := helper.DeletedScope(ctx, c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error occurred while retrieving order items"})
+			apierrors.Write(c, http.StatusForbidden, err.Error())
 			return
 		}
-		defer cursor.Close(ctx)
 
-		var allOrderItems []models.OrderItem
-		if err := cursor.All(ctx, &allOrderItems); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error occurred while decoding order items"})
+		query, err := helper.ParseListQuery(c, orderItemListSpec)
+		if err != nil {
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		if len(allOrderItems) == 0 {
-			c.JSON(http.StatusNotFound, gin.H{"message": "No order items found"})
+		if helper.UsesCursor(c) {
+			cursor, err := helper.ParseCursor(c)
+			if err != nil {
+				apierrors.Write(c, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			orderItems, page, err := ctrl.orderItems.Scroll(ctx, query, cursor)
+			if err != nil {
+				apierrors.Internal(c, "Error occurred while retrieving order items", err)
+				return
+			}
+
+			c.JSON(http.StatusOK, helper.NewCursorListResponse(c, orderItems, page, query))
+			return
+		}
+
+		orderItems, total, err := ctrl.orderItems.List(ctx, query)
+		if err != nil {
+			apierrors.Internal(c, "Error occurred while retrieving order items", err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"totalCount": len(allOrderItems), "orderItems": allOrderItems})
+		c.JSON(http.StatusOK, helper.NewListResponse(c, orderItems, total, query))
 	}
 }
 
-func GetOrderItemByID() gin.HandlerFunc {
+func (ctrl *OrderItemController) GetOrderItemByID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
+		ctx, err := helper.DeletedScope(ctx, c)
+		if err != nil {
+			apierrors.Write(c, http.StatusForbidden, err.Error())
+			return
+		}
+
 		orderItemId := c.Param("orderItemId")
-		var orderItem models.OrderItem
 
-		err := orderItemCollection.FindOne(ctx, bson.M{"orderItemId": orderItemId}).Decode(&orderItem)
-		if err != nil {
-			if err == mongo.ErrNoDocuments {
-				c.JSON(http.StatusNotFound, gin.H{"error": "Order item not found"})
-				return
-			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error occurred while retrieving the order item"})
+		orderItem, err := ctrl.orderItems.FindByID(ctx, orderItemId)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Order item not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Error occurred while retrieving the order item", err)
 			return
 		}
 
+		helper.SetETag(c, orderItem.Version)
 		c.JSON(http.StatusOK, gin.H{"orderItem": orderItem})
 	}
 }
 
-func GetOrderItemsByOrderID() gin.HandlerFunc {
+func (ctrl *OrderItemController) GetOrderItemsByOrderID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
+		ctx, err := helper.DeletedScope(ctx, c)
+		if err != nil {
+			apierrors.Write(c, http.StatusForbidden, err.Error())
+			return
+		}
+
 		orderId := c.Param("orderId")
 
-		cursor, err := orderItemCollection.Find(ctx, bson.M{"orderId": orderId})
+		query, err := helper.ParseListQuery(c, orderItemListSpec)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve order items"})
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		query = query.Where("orderId", repositories.OpEq, orderId)
+
+		if helper.UsesCursor(c) {
+			cursor, err := helper.ParseCursor(c)
+			if err != nil {
+				apierrors.Write(c, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			orderItems, page, err := ctrl.orderItems.Scroll(ctx, query, cursor)
+			if err != nil {
+				apierrors.Internal(c, "Failed to retrieve order items", err)
+				return
+			}
+
+			c.JSON(http.StatusOK, helper.NewCursorListResponse(c, orderItems, page, query))
 			return
 		}
-		defer cursor.Close(ctx)
 
-		var orderItems []models.OrderItem
-		if err := cursor.All(ctx, &orderItems); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error while parsing order items for order"})
+		orderItems, total, err := ctrl.orderItems.List(ctx, query)
+		if err != nil {
+			apierrors.Internal(c, "Failed to retrieve order items", err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"totalCount": len(orderItems), "orderItems": orderItems})
+		c.JSON(http.StatusOK, helper.NewListResponse(c, orderItems, total, query))
 	}
 }
 
-func UpdateOrderItemByID() gin.HandlerFunc {
+func (ctrl *OrderItemController) UpdateOrderItemByID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
 		var orderItem models.OrderItem
 		orderItemId := c.Param("orderItemId")
 
-		if err := c.BindJSON(&orderItem); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		if err := c.ShouldBindJSON(&orderItem); err != nil {
+			apierrors.InvalidBody(c, err)
+			return
+		}
+
+		existing, err := ctrl.orderItems.FindByID(ctx, orderItemId)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Order item not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Error occurred while retrieving the order item", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, existing.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Order item has been modified; fetch it again and retry")
+			return
+		}
+
+		if orderItem.UnitPrice == nil && orderItem.Quantity == nil && orderItem.FoodID == nil {
+			apierrors.Write(c, http.StatusBadRequest, "No fields to update")
+			return
+		}
+
+		if orderItem.FoodID != nil && (existing.BundleID != nil || len(existing.Components) > 0) {
+			apierrors.New(http.StatusBadRequest, "The food of a bundle item cannot be changed; remove the item and add the food instead").
+				WithCode("bundle_item").With("orderItemId", existing.OrderItemID).Send(c)
 			return
 		}
 
-		filter := bson.M{"orderItemId": orderItemId}
-		updateObj := bson.D{}
+		if orderItem.FoodID != nil && (existing.FoodID == nil || *existing.FoodID != *orderItem.FoodID) {
+			food, err := ctrl.foods.FindByID(ctx, *orderItem.FoodID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				apierrors.New(http.StatusNotFound, "Food item not found").With("foodId", *orderItem.FoodID).Send(c)
+				return
+			} else if err != nil {
+				apierrors.Internal(c, "Error fetching food item", err)
+				return
+			}
+
+			order, err := ctrl.orders.FindByID(ctx, existing.OrderID)
+			if err != nil {
+				apierrors.Internal(c, "Error fetching order", err)
+				return
+			}
+
+			unitPrice, err := helper.GetEffectivePrice(ctx, ctrl.prices, *food, order.OrderDate)
+			if err != nil {
+				utils.LoggerFrom(ctx).Error("Failed to resolve food price", zap.String("foodId", food.FoodID), zap.Error(err))
+				apierrors.New(http.StatusInternalServerError, "Failed to resolve food price").With("foodId", food.FoodID).Send(c)
+				return
+			}
+
+			existing.FoodID = orderItem.FoodID
+			existing.Allergens = food.Allergens
+			existing.UnitPrice = unitPrice
+		}
 
 		if orderItem.UnitPrice != nil {
-			updateObj = append(updateObj, bson.E{Key: "unitprice", Value: *orderItem.UnitPrice})
+			existing.UnitPrice = orderItem.UnitPrice
 		}
 
 		if orderItem.Quantity != nil {
-			updateObj = append(updateObj, bson.E{Key: "quantity", Value: *orderItem.Quantity})
+			existing.Quantity = orderItem.Quantity
 		}
 
-		if orderItem.FoodID != nil {
-			updateObj = append(updateObj, bson.E{Key: "foodId", Value: *orderItem.FoodID})
+		existing.UpdatedAt = time.Now().UTC()
+
+		if err := ctrl.orderItems.Update(ctx, existing); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Order item has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to update order item", err)
+			return
 		}
 
-		orderItem.UpdatedAt = time.Now().UTC()
-		updateObj = append(updateObj, bson.E{Key: "updatedAt", Value: orderItem.UpdatedAt})
+		ctrl.refreshInvoices(ctx, existing.OrderID)
 
-		if len(updateObj) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "No fields to update"})
+		helper.SetETag(c, existing.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Order item updated successfully", "orderItem": existing})
+	}
+}
+
+func (ctrl *OrderItemController) DeleteOrderItemByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		orderItemId := c.Param("orderItemId")
+
+		orderItem, err := ctrl.orderItems.FindByID(ctx, orderItemId)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Order item not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve order item", err)
 			return
 		}
 
-		opts := options.FindOneAndUpdate().SetReturnDocument(options.After).SetUpsert(true)
+		if !helper.CheckIfMatch(c, orderItem.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Order item has been modified; fetch it again and retry")
+			return
+		}
 
-		var updatedOrderItem models.OrderItem
-		err := orderItemCollection.FindOneAndUpdate(ctx, filter, bson.D{{Key: "$set", Value: updateObj}}, opts).Decode(&updatedOrderItem)
+		deletedAt, deletedBy := helper.DeletionStamp(c)
 
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update order item"})
+		orderItem.DeletedAt = &deletedAt
+		orderItem.DeletedBy = deletedBy
+		orderItem.UpdatedAt = deletedAt
+
+		if err := ctrl.orderItems.Update(ctx, orderItem); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Order item has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to delete order item", err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"message": "Order item updated successfully", "orderItem": updatedOrderItem})
+		ctrl.refreshInvoices(ctx, orderItem.OrderID)
+
+		helper.SetETag(c, orderItem.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Order item deleted successfully", "orderItem": orderItem})
+	}
+}
+
+func (ctrl *OrderItemController) RestoreOrderItemByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
+		defer cancel()
+
+		orderItemId := c.Param("orderItemId")
+
+		orderItem, err := ctrl.orderItems.FindByID(repositories.WithDeleted(ctx), orderItemId)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Order item not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve order item", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, orderItem.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Order item has been modified; fetch it again and retry")
+			return
+		}
+
+		if orderItem.DeletedAt == nil {
+			apierrors.Write(c, http.StatusConflict, "Order item is not deleted")
+			return
+		}
+
+		if orderItem.OrderID != "" {
+			_, err := ctrl.orders.FindByID(ctx, orderItem.OrderID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				apierrors.Write(c, http.StatusConflict, "The order of this order item is deleted; restore it first")
+				return
+			} else if err != nil {
+				apierrors.Internal(c, "Failed to retrieve order", err)
+				return
+			}
+		}
+
+		orderItem.DeletedAt = nil
+		orderItem.DeletedBy = nil
+		orderItem.UpdatedAt = time.Now().UTC()
+
+		if err := ctrl.orderItems.Update(ctx, orderItem); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Order item has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to restore order item", err)
+			return
+		}
+
+		ctrl.refreshInvoices(ctx, orderItem.OrderID)
+
+		helper.SetETag(c, orderItem.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Order item restored successfully", "orderItem": orderItem})
+	}
+}
+
+func (ctrl *OrderItemController) refreshInvoices(ctx context.Context, orderID string) {
+	if err := helper.RefreshInvoiceTotals(ctx, ctrl.evaluator, ctrl.invoices, ctrl.orders, orderID); err != nil {
+		utils.LoggerFrom(ctx).Error("Failed to refresh invoice totals", zap.String("orderId", orderID), zap.Error(err))
 	}
 }
@@ -0,0 +1,139 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/controllers"
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/datarohit/go-restaurant-management-backend-project/routes"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type orderItemResponse struct {
+	OrderItem models.OrderItem `json:"orderItem"`
+}
+
+func (s *testServer) createTable(token string) string {
+	s.t.Helper()
+
+	res := s.do(request{method: http.MethodPost, path: "/api/v1/tables/", token: token, body: gin.H{"tableNumber": 1, "numberOfGuests": 2}})
+	expectStatus(s.t, res, http.StatusOK)
+
+	var table struct {
+		Table models.Table `json:"table"`
+	}
+	decode(s.t, res, &table)
+	return table.Table.TableID
+}
+
+func (s *testServer) orderFood(token, tableID, foodID string) models.OrderItem {
+	s.t.Helper()
+
+	res := s.do(request{method: http.MethodPost, path: "/api/v1/orderItems/", token: token,
+		body: gin.H{"tableId": tableID, "orderItems": []gin.H{{"foodId": foodID, "quantity": "M"}}}})
+	expectStatus(s.t, res, http.StatusOK)
+
+	var created struct {
+		OrderItems []models.OrderItem `json:"orderItems"`
+	}
+	decode(s.t, res, &created)
+	return created.OrderItems[0]
+}
+
+func TestChangingTheFoodRepricesTheOrderItem(t *testing.T) {
+	s := newTestServer(t)
+	token := s.adminToken()
+	_, pizza := s.createMenuWithFood("Lunch")
+	_, pasta := s.createMenuWithFood("Dinner")
+
+	if _, err := helper.RecordFoodPrice(context.Background(), s.repos.FoodPrices, pasta, 12, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("recording price: %v", err)
+	}
+
+	item := s.orderFood(token, s.createTable(token), pizza)
+	if *item.UnitPrice != 9.5 {
+		t.Fatalf("expected the pizza price, got %v", *item.UnitPrice)
+	}
+	path := "/api/v1/orderItems/" + item.OrderItemID
+
+	res := s.do(request{method: http.MethodPatch, path: path, token: token, body: gin.H{"foodId": pasta}})
+	expectStatus(t, res, http.StatusOK)
+
+	var updated orderItemResponse
+	decode(t, res, &updated)
+	if *updated.OrderItem.FoodID != pasta || *updated.OrderItem.UnitPrice != 12 {
+		t.Fatalf("expected the pasta at its effective price, got %+v", updated.OrderItem)
+	}
+
+	res = s.do(request{method: http.MethodPatch, path: path, token: token, body: gin.H{"foodId": pizza, "unitPrice": 5}})
+	expectStatus(t, res, http.StatusOK)
+
+	decode(t, res, &updated)
+	if *updated.OrderItem.FoodID != pizza || *updated.OrderItem.UnitPrice != 5 {
+		t.Fatalf("expected an explicit unit price to win, got %+v", updated.OrderItem)
+	}
+
+	res = s.do(request{method: http.MethodPatch, path: path, token: token, body: gin.H{"foodId": primitive.NewObjectID().Hex()}})
+	expectStatus(t, res, http.StatusNotFound)
+}
+
+func TestBundleItemsKeepTheirFood(t *testing.T) {
+	s := newTestServer(t)
+	token := s.adminToken()
+	_, foodID := s.createMenuWithFood("Lunch")
+
+	now := time.Now().UTC()
+	quantity, price, bundleID := "M", 15.0, primitive.NewObjectID().Hex()
+	item := models.OrderItem{
+		ID:         primitive.NewObjectID(),
+		Quantity:   &quantity,
+		UnitPrice:  &price,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		BundleID:   &bundleID,
+		Components: []models.OrderItemComponent{{FoodID: foodID}},
+		OrderID:    primitive.NewObjectID().Hex(),
+	}
+	item.OrderItemID = item.ID.Hex()
+	if err := s.repos.OrderItems.CreateMany(context.Background(), []models.OrderItem{item}); err != nil {
+		t.Fatalf("creating order item: %v", err)
+	}
+
+	res := s.do(request{method: http.MethodPatch, path: "/api/v1/orderItems/" + item.OrderItemID, token: token, body: gin.H{"foodId": foodID}})
+	expectStatus(t, res, http.StatusBadRequest)
+	expectCode(t, res, "bundle_item")
+}
+
+type failingBundleRepository struct {
+	repositories.BundleRepository
+}
+
+func (failingBundleRepository) FindByID(ctx context.Context, bundleID string) (*models.Bundle, error) {
+	return nil, errors.New("connection reset")
+}
+
+func TestOrderingABundleReportsLookupFailures(t *testing.T) {
+	s := newTestServer(t)
+	token := s.adminToken()
+	tableID := s.createTable(token)
+
+	router := gin.New()
+	routes.OrderItemRoutes(router, controllers.NewOrderItemController(s.repos.Orders, s.repos.OrderItems, s.repos.Foods, s.repos.FoodPrices,
+		failingBundleRepository{s.repos.Bundles}, s.repos.Invoices, nil))
+	broken := &testServer{t: t, router: router, repos: s.repos}
+
+	res := broken.do(request{method: http.MethodPost, path: "/api/v1/orderItems/",
+		body: gin.H{"tableId": tableID, "orderItems": []gin.H{{"bundleId": primitive.NewObjectID().Hex(), "quantity": "M"}}}})
+	expectStatus(t, res, http.StatusInternalServerError)
+
+	res = s.do(request{method: http.MethodPost, path: "/api/v1/orderItems/", token: token,
+		body: gin.H{"tableId": tableID, "orderItems": []gin.H{{"bundleId": primitive.NewObjectID().Hex(), "quantity": "M"}}}})
+	expectStatus(t, res, http.StatusNotFound)
+}
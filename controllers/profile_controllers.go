@@ -0,0 +1,239 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type UpdateProfileRequest struct {
+	FirstName *string `json:"firstName" validate:"omitempty,min=2,max=100"`
+	LastName  *string `json:"lastName" validate:"omitempty,min=2,max=100"`
+	Phone     *string `json:"phone" validate:"omitempty,min=3,max=20"`
+	Avatar    *string `json:"avatar" validate:"omitempty,url"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" validate:"required"`
+	NewPassword     string `json:"newPassword" validate:"required,min=6,nefield=CurrentPassword"`
+}
+
+func (ctrl *UserController) GetMe() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		user, ok := ctrl.findUser(c, ctx, c.GetString("uid"))
+		if !ok {
+			return
+		}
+
+		helper.SetETag(c, user.Version)
+		c.JSON(http.StatusOK, gin.H{"user": models.NewUserResponse(*user)})
+	}
+}
+
+func (ctrl *UserController) UpdateMe() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		var request UpdateProfileRequest
+		if !bindAccountRequest(c, &request) {
+			return
+		}
+
+		if request.FirstName == nil && request.LastName == nil && request.Phone == nil && request.Avatar == nil {
+			apierrors.Write(c, http.StatusBadRequest, "No fields to update")
+			return
+		}
+
+		user, ok := ctrl.findUser(c, ctx, c.GetString("uid"))
+		if !ok {
+			return
+		}
+
+		if !helper.CheckIfMatch(c, user.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "User has been modified; fetch it again and retry")
+			return
+		}
+
+		if request.Phone != nil && (user.Phone == nil || *request.Phone != *user.Phone) {
+			if taken, err := ctrl.users.ExistsByPhone(ctx, *request.Phone, user.UserID); err != nil {
+				apierrors.Internal(c, "Error checking phone number", err)
+				return
+			} else if taken {
+				apierrors.Write(c, http.StatusConflict, "Another user already has this phone number")
+				return
+			}
+			user.Phone = request.Phone
+		}
+
+		if request.FirstName != nil {
+			user.FirstName = request.FirstName
+		}
+		if request.LastName != nil {
+			user.LastName = request.LastName
+		}
+		if request.Avatar != nil {
+			user.Avatar = request.Avatar
+		}
+		user.UpdatedAt = time.Now().UTC()
+
+		if err := ctrl.users.Update(ctx, user); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "User has been modified; fetch it again and retry")
+			return
+		} else if errors.Is(err, repositories.ErrDuplicate) {
+			apierrors.Write(c, http.StatusConflict, "Another user already has this phone number")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Error updating profile", err)
+			return
+		}
+
+		helper.SetETag(c, user.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Profile updated successfully", "user": models.NewUserResponse(*user)})
+	}
+}
+
+func (ctrl *UserController) ChangePassword() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		var request ChangePasswordRequest
+		if !bindAccountRequest(c, &request) {
+			return
+		}
+
+		user, ok := ctrl.findUser(c, ctx, c.GetString("uid"))
+		if !ok {
+			return
+		}
+
+		if valid, _ := helper.VerifyPassword(*user.Password, request.CurrentPassword); !valid {
+			apierrors.New(http.StatusBadRequest, "The current password is incorrect").WithCode("incorrect_password").Send(c)
+			return
+		}
+
+		hashedPassword, err := helper.HashPassword(&request.NewPassword)
+		if err != nil {
+			apierrors.Internal(c, "Error hashing password", err)
+			return
+		}
+
+		if err := ctrl.users.UpdatePassword(ctx, user.UserID, *hashedPassword); err != nil {
+			apierrors.Internal(c, "Error updating password", err)
+			return
+		}
+
+		accessToken, refreshToken, err := ctrl.tokens.IssueTokens(*user)
+		if err != nil {
+			apierrors.Internal(c, "Error generating tokens", err)
+			return
+		}
+
+		if err := helper.UpdateAllTokens(ctx, ctrl.users, accessToken, refreshToken, user.UserID); err != nil {
+			apierrors.Internal(c, "Error updating tokens", err)
+			return
+		}
+
+		user, ok = ctrl.findUser(c, ctx, user.UserID)
+		if !ok {
+			return
+		}
+
+		utils.LoggerFrom(ctx).Info("Password changed; existing tokens revoked", zap.String("userId", user.UserID))
+		c.JSON(http.StatusOK, models.AuthResponse{
+			Message:      "Password changed successfully",
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+			User:         models.NewUserResponse(*user),
+		})
+	}
+}
+
+func (ctrl *UserController) DeactivateUser() gin.HandlerFunc {
+	return ctrl.setDeactivated(true)
+}
+
+func (ctrl *UserController) ReactivateUser() gin.HandlerFunc {
+	return ctrl.setDeactivated(false)
+}
+
+func (ctrl *UserController) setDeactivated(deactivated bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		userID := c.Param("userId")
+		if deactivated && userID == c.GetString("uid") {
+			apierrors.Write(c, http.StatusBadRequest, "You cannot deactivate your own account")
+			return
+		}
+
+		user, ok := ctrl.findUser(c, ctx, userID)
+		if !ok {
+			return
+		}
+
+		if !helper.CheckIfMatch(c, user.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "User has been modified; fetch it again and retry")
+			return
+		}
+
+		if user.Deactivated != deactivated {
+			now := time.Now().UTC()
+			user.Deactivated = deactivated
+			user.DeactivatedAt = nil
+			if deactivated {
+				user.DeactivatedAt = &now
+				user.TokensRevokedAt = &now
+				user.AccessTokenHash = nil
+				user.RefreshTokenHash = nil
+			}
+			user.UpdatedAt = now
+
+			if err := ctrl.users.Update(ctx, user); errors.Is(err, repositories.ErrVersionConflict) {
+				apierrors.Write(c, http.StatusPreconditionFailed, "User has been modified; fetch it again and retry")
+				return
+			} else if err != nil {
+				apierrors.Internal(c, "Error updating user", err)
+				return
+			}
+
+			utils.LoggerFrom(ctx).Warn("User account status changed",
+				zap.String("userId", user.UserID),
+				zap.Bool("deactivated", deactivated))
+		}
+
+		message := "User reactivated successfully"
+		if deactivated {
+			message = "User deactivated successfully"
+		}
+
+		helper.SetETag(c, user.Version)
+		c.JSON(http.StatusOK, gin.H{"message": message, "user": models.NewUserResponse(*user)})
+	}
+}
+
+func (ctrl *UserController) findUser(c *gin.Context, ctx context.Context, userID string) (*models.User, bool) {
+	user, err := ctrl.users.FindByID(ctx, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		apierrors.Write(c, http.StatusNotFound, "User not found")
+		return nil, false
+	} else if err != nil {
+		apierrors.Internal(c, "Error retrieving user", err)
+		return nil, false
+	}
+	return user, true
+}
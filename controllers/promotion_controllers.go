@@ -0,0 +1,378 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PromotionController struct {
+	promotions repositories.PromotionRepository
+	orders     repositories.OrderRepository
+	evaluator  *helper.PromotionEvaluator
+}
+
+func NewPromotionController(promotions repositories.PromotionRepository, orders repositories.OrderRepository, evaluator *helper.PromotionEvaluator) *PromotionController {
+	return &PromotionController{promotions: promotions, orders: orders, evaluator: evaluator}
+}
+
+func (ctrl *PromotionController) CreatePromotion() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		var promotion models.Promotion
+		if err := c.ShouldBindJSON(&promotion); err != nil {
+			apierrors.InvalidBody(c, err)
+			return
+		}
+
+		if err := validate.Struct(promotion); err != nil {
+			apierrors.Validation(c, err)
+			return
+		}
+
+		if err := validatePromotionRules(promotion); err != nil {
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if promotion.Active == nil {
+			active := true
+			promotion.Active = &active
+		}
+
+		currentTime := time.Now().UTC()
+		promotion.CreatedAt = currentTime
+		promotion.UpdatedAt = currentTime
+		promotion.ID = primitive.NewObjectID()
+		promotion.PromotionID = promotion.ID.Hex()
+
+		if err := ctrl.promotions.Create(ctx, &promotion); err != nil {
+			apierrors.Internal(c, "Failed to create promotion", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Promotion created successfully", "promotion": promotion})
+	}
+}
+
+var promotionListSpec = helper.ListSpec{
+	Filters: map[string]helper.FieldType{
+		"name":      helper.StringField,
+		"type":      helper.StringField,
+		"active":    helper.BoolField,
+		"startDate": helper.TimeField,
+		"endDate":   helper.TimeField,
+		"createdAt": helper.TimeField,
+	},
+	Sorts:       []string{"name", "startDate", "endDate", "createdAt"},
+	DefaultSort: "-createdAt",
+}
+
+func (ctrl *PromotionController) GetAllPromotions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		ctx, err := helper.DeletedScope(ctx, c)
+		if err != nil {
+			apierrors.Write(c, http.StatusForbidden, err.Error())
+			return
+		}
+
+		query, err := helper.ParseListQuery(c, promotionListSpec)
+		if err != nil {
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		promotions, total, err := ctrl.promotions.List(ctx, query)
+		if err != nil {
+			apierrors.Internal(c, "Failed to retrieve promotions", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, helper.NewListResponse(c, promotions, total, query))
+	}
+}
+
+func (ctrl *PromotionController) GetPromotionByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		ctx, err := helper.DeletedScope(ctx, c)
+		if err != nil {
+			apierrors.Write(c, http.StatusForbidden, err.Error())
+			return
+		}
+
+		promotionID := c.Param("promotionId")
+
+		promotion, err := ctrl.promotions.FindByID(ctx, promotionID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Promotion not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve promotion", err)
+			return
+		}
+
+		helper.SetETag(c, promotion.Version)
+		c.JSON(http.StatusOK, gin.H{"promotion": promotion})
+	}
+}
+
+func (ctrl *PromotionController) UpdatePromotionByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		promotionID := c.Param("promotionId")
+
+		var promotion models.Promotion
+		if err := c.ShouldBindJSON(&promotion); err != nil {
+			apierrors.InvalidBody(c, err)
+			return
+		}
+
+		existing, err := ctrl.promotions.FindByID(ctx, promotionID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Promotion not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve promotion", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, existing.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Promotion has been modified; fetch it again and retry")
+			return
+		}
+
+		updated := false
+
+		if promotion.Name != nil {
+			existing.Name = promotion.Name
+			updated = true
+		}
+		if promotion.Description != nil {
+			existing.Description = promotion.Description
+			updated = true
+		}
+		if promotion.Type != nil {
+			existing.Type = promotion.Type
+			updated = true
+		}
+		if promotion.DiscountValue != nil {
+			existing.DiscountValue = promotion.DiscountValue
+			updated = true
+		}
+		if promotion.BuyQuantity != nil {
+			existing.BuyQuantity = promotion.BuyQuantity
+			updated = true
+		}
+		if promotion.GetQuantity != nil {
+			existing.GetQuantity = promotion.GetQuantity
+			updated = true
+		}
+		if promotion.FoodIDs != nil {
+			existing.FoodIDs = promotion.FoodIDs
+			updated = true
+		}
+		if promotion.MenuIDs != nil {
+			existing.MenuIDs = promotion.MenuIDs
+			updated = true
+		}
+		if promotion.Categories != nil {
+			existing.Categories = promotion.Categories
+			updated = true
+		}
+		if promotion.MinimumSpend != nil {
+			existing.MinimumSpend = promotion.MinimumSpend
+			updated = true
+		}
+		if promotion.StartDate != nil {
+			existing.StartDate = promotion.StartDate
+			updated = true
+		}
+		if promotion.EndDate != nil {
+			existing.EndDate = promotion.EndDate
+			updated = true
+		}
+		if promotion.DaysOfWeek != nil {
+			existing.DaysOfWeek = promotion.DaysOfWeek
+			updated = true
+		}
+		if promotion.StartTime != nil {
+			existing.StartTime = promotion.StartTime
+			updated = true
+		}
+		if promotion.EndTime != nil {
+			existing.EndTime = promotion.EndTime
+			updated = true
+		}
+		if promotion.Active != nil {
+			existing.Active = promotion.Active
+			updated = true
+		}
+
+		if !updated {
+			apierrors.Write(c, http.StatusBadRequest, "No fields to update")
+			return
+		}
+
+		if err := validate.Struct(existing); err != nil {
+			apierrors.Validation(c, err)
+			return
+		}
+
+		if err := validatePromotionRules(*existing); err != nil {
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		existing.UpdatedAt = time.Now().UTC()
+
+		if err := ctrl.promotions.Update(ctx, existing); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Promotion has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to update promotion", err)
+			return
+		}
+
+		helper.SetETag(c, existing.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Promotion updated successfully", "promotion": existing})
+	}
+}
+
+func (ctrl *PromotionController) PreviewOrderPromotions() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		orderID := c.Param("orderId")
+
+		order, err := ctrl.orders.FindByID(ctx, orderID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Order not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve order", err)
+			return
+		}
+
+		summary, err := ctrl.evaluator.EvaluateOrder(ctx, *order)
+		if err != nil {
+			apierrors.Internal(c, "Failed to evaluate promotions", err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"orderId": order.OrderID, "preview": summary})
+	}
+}
+
+func validatePromotionRules(promotion models.Promotion) error {
+	if (promotion.StartTime == nil) != (promotion.EndTime == nil) {
+		return errors.New("startTime and endTime must be provided together")
+	}
+
+	if promotion.StartDate != nil && promotion.EndDate != nil && promotion.EndDate.Before(*promotion.StartDate) {
+		return errors.New("endDate must be after startDate")
+	}
+
+	return nil
+}
+
+func (ctrl *PromotionController) DeletePromotionByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		promotionID := c.Param("promotionId")
+
+		promotion, err := ctrl.promotions.FindByID(ctx, promotionID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Promotion not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve promotion", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, promotion.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Promotion has been modified; fetch it again and retry")
+			return
+		}
+
+		deletedAt, deletedBy := helper.DeletionStamp(c)
+
+		promotion.DeletedAt = &deletedAt
+		promotion.DeletedBy = deletedBy
+		promotion.UpdatedAt = deletedAt
+
+		if err := ctrl.promotions.Update(ctx, promotion); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Promotion has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to delete promotion", err)
+			return
+		}
+
+		helper.SetETag(c, promotion.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Promotion deleted successfully", "promotion": promotion})
+	}
+}
+
+func (ctrl *PromotionController) RestorePromotionByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		promotionID := c.Param("promotionId")
+
+		promotion, err := ctrl.promotions.FindByID(repositories.WithDeleted(ctx), promotionID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Promotion not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve promotion", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, promotion.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Promotion has been modified; fetch it again and retry")
+			return
+		}
+
+		if promotion.DeletedAt == nil {
+			apierrors.Write(c, http.StatusConflict, "Promotion is not deleted")
+			return
+		}
+
+		promotion.DeletedAt = nil
+		promotion.DeletedBy = nil
+		promotion.UpdatedAt = time.Now().UTC()
+
+		if err := ctrl.promotions.Update(ctx, promotion); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Promotion has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to restore promotion", err)
+			return
+		}
+
+		helper.SetETag(c, promotion.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Promotion restored successfully", "promotion": promotion})
+	}
+}
@@ -0,0 +1,60 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/gin-gonic/gin"
+)
+
+type promotionResponse struct {
+	Promotion models.Promotion `json:"promotion"`
+}
+
+func TestCreatePromotionValidatesRuleFields(t *testing.T) {
+	s := newTestServer(t)
+	token := s.adminToken()
+
+	tests := []struct {
+		name   string
+		body   gin.H
+		status int
+	}{
+		{"percentage without value", gin.H{"name": "Happy hour", "type": "PERCENTAGE"}, http.StatusBadRequest},
+		{"percentage over 100", gin.H{"name": "Happy hour", "type": "PERCENTAGE", "discountValue": 150}, http.StatusBadRequest},
+		{"percentage of zero", gin.H{"name": "Happy hour", "type": "PERCENTAGE", "discountValue": 0}, http.StatusBadRequest},
+		{"percentage", gin.H{"name": "Happy hour", "type": "PERCENTAGE", "discountValue": 100}, http.StatusOK},
+		{"fixed without value", gin.H{"name": "Five off", "type": "FIXED"}, http.StatusBadRequest},
+		{"fixed over 100", gin.H{"name": "Big spender", "type": "FIXED", "discountValue": 150}, http.StatusOK},
+		{"buy x get y without get", gin.H{"name": "Two for one", "type": "BUY_X_GET_Y", "buyQuantity": 1}, http.StatusBadRequest},
+		{"buy x get y", gin.H{"name": "Two for one", "type": "BUY_X_GET_Y", "buyQuantity": 1, "getQuantity": 1}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := s.do(request{method: http.MethodPost, path: "/api/v1/promotions/", token: token, body: tt.body})
+			expectStatus(t, res, tt.status)
+			if tt.status == http.StatusBadRequest {
+				expectCode(t, res, "validation_failed")
+			}
+		})
+	}
+}
+
+func TestUpdatePromotionKeepsThePercentageCap(t *testing.T) {
+	s := newTestServer(t)
+	token := s.adminToken()
+
+	res := s.do(request{method: http.MethodPost, path: "/api/v1/promotions/", token: token,
+		body: gin.H{"name": "Five off", "type": "FIXED", "discountValue": 150}})
+	expectStatus(t, res, http.StatusOK)
+
+	var created promotionResponse
+	decode(t, res, &created)
+
+	res = s.do(request{method: http.MethodPatch, path: "/api/v1/promotions/" + created.Promotion.PromotionID, token: token,
+		body: gin.H{"type": "PERCENTAGE"}})
+	expectStatus(t, res, http.StatusBadRequest)
+	expectCode(t, res, "validation_failed")
+}
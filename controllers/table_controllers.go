@@ -2,34 +2,40 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
-	"github.com/datarohit/go-restaurant-management-backend-project/database"
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
 	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-var tableCollection *mongo.Collection = database.OpenCollection(database.Client, "table")
+type TableController struct {
+	tables repositories.TableRepository
+}
+
+func NewTableController(tables repositories.TableRepository) *TableController {
+	return &TableController{tables: tables}
+}
 
-func CreateTable() gin.HandlerFunc {
+func (ctrl *TableController) CreateTable() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
 		var table models.Table
-		if err := c.BindJSON(&table); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		if err := c.ShouldBindJSON(&table); err != nil {
+			apierrors.InvalidBody(c, err)
 			return
 		}
 
 		if validationErr := validate.Struct(table); validationErr != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error()})
+			apierrors.Validation(c, validationErr)
 			return
 		}
 
@@ -38,9 +44,8 @@ func CreateTable() gin.HandlerFunc {
 		table.ID = primitive.NewObjectID()
 		table.TableID = table.ID.Hex()
 
-		_, err := tableCollection.InsertOne(ctx, table)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create table"})
+		if err := ctrl.tables.Create(ctx, &table); err != nil {
+			apierrors.Internal(c, "Failed to create table", err)
 			return
 		}
 
@@ -48,108 +53,213 @@ func CreateTable() gin.HandlerFunc {
 	}
 }
 
-func GetAllTables() gin.HandlerFunc {
+var tableListSpec = helper.ListSpec{
+	Filters: map[string]helper.FieldType{
+		"tableNumber":    helper.NumberField,
+		"numberOfGuests": helper.NumberField,
+		"createdAt":      helper.TimeField,
+		"updatedAt":      helper.TimeField,
+	},
+	Sorts:       []string{"tableNumber", "numberOfGuests", "createdAt", "updatedAt"},
+	DefaultSort: "tableNumber",
+}
+
+func (ctrl *TableController) GetAllTables() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
-		cursor, err := tableCollection.Find(ctx, bson.M{})
+		ctx, err := helper.DeletedScope(ctx, c)
+		if err != nil {
+			apierrors.Write(c, http.StatusForbidden, err.Error())
+			return
+		}
+
+		query, err := helper.ParseListQuery(c, tableListSpec)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve tables"})
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
 			return
 		}
-		defer cursor.Close(ctx)
 
-		var tables []models.Table
-		if err := cursor.All(ctx, &tables); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error while parsing tables"})
+		tables, total, err := ctrl.tables.List(ctx, query)
+		if err != nil {
+			apierrors.Internal(c, "Failed to retrieve tables", err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"totalCount": len(tables), "tables": tables})
+		c.JSON(http.StatusOK, helper.NewListResponse(c, tables, total, query))
 	}
 }
 
-func GetTableByID() gin.HandlerFunc {
+func (ctrl *TableController) GetTableByID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
+		ctx, err := helper.DeletedScope(ctx, c)
+		if err != nil {
+			apierrors.Write(c, http.StatusForbidden, err.Error())
+			return
+		}
+
 		tableID := c.Param("tableId")
 		if tableID == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Table ID is required"})
+			apierrors.Write(c, http.StatusBadRequest, "Table ID is required")
 			return
 		}
 
-		var table models.Table
-		err := tableCollection.FindOne(ctx, bson.M{"tableId": tableID}).Decode(&table)
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Table not found"})
+		table, err := ctrl.tables.FindByID(ctx, tableID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Table not found")
 			return
 		} else if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve table"})
+			apierrors.Internal(c, "Failed to retrieve table", err)
 			return
 		}
 
+		helper.SetETag(c, table.Version)
 		c.JSON(http.StatusOK, gin.H{"table": table})
 	}
 }
 
-func UpdateTableByID() gin.HandlerFunc {
+func (ctrl *TableController) UpdateTableByID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
 		tableID := c.Param("tableId")
 		if tableID == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Table ID is required"})
+			apierrors.Write(c, http.StatusBadRequest, "Table ID is required")
 			return
 		}
 
 		var table models.Table
-		if err := c.BindJSON(&table); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON payload"})
+		if err := c.ShouldBindJSON(&table); err != nil {
+			apierrors.InvalidBody(c, err)
+			return
+		}
+
+		existing, err := ctrl.tables.FindByID(ctx, tableID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Table not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve table", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, existing.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Table has been modified; fetch it again and retry")
+			return
+		}
+
+		if table.NumberOfGuests == nil && table.TableNumber == nil {
+			apierrors.Write(c, http.StatusBadRequest, "No fields to update")
 			return
 		}
 
-		updateObj := bson.D{}
 		if table.NumberOfGuests != nil {
-			updateObj = append(updateObj, bson.E{Key: "numberOfGuests", Value: table.NumberOfGuests})
+			existing.NumberOfGuests = table.NumberOfGuests
 		}
 
 		if table.TableNumber != nil {
-			updateObj = append(updateObj, bson.E{Key: "tableNumber", Value: table.TableNumber})
+			existing.TableNumber = table.TableNumber
 		}
 
-		table.UpdatedAt = time.Now().UTC()
-		updateObj = append(updateObj, bson.E{Key: "updatedAt", Value: table.UpdatedAt})
+		existing.UpdatedAt = time.Now().UTC()
 
-		if len(updateObj) == 0 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "No fields to update"})
+		if err := ctrl.tables.Update(ctx, existing); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Table has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to update table", err)
 			return
 		}
 
-		filter := bson.M{"tableId": tableID}
-		opts := options.Update().SetUpsert(false)
+		helper.SetETag(c, existing.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Table updated successfully", "table": existing})
+	}
+}
 
-		result, err := tableCollection.UpdateOne(ctx, filter, bson.D{{Key: "$set", Value: updateObj}}, opts)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update table"})
+func (ctrl *TableController) DeleteTableByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		tableID := c.Param("tableId")
+
+		table, err := ctrl.tables.FindByID(ctx, tableID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Table not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve table", err)
 			return
 		}
 
-		if result.MatchedCount == 0 {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Table not found"})
+		if !helper.CheckIfMatch(c, table.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Table has been modified; fetch it again and retry")
 			return
 		}
 
-		var updatedTable models.Table
-		err = tableCollection.FindOne(ctx, filter).Decode(&updatedTable)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve updated table"})
+		deletedAt, deletedBy := helper.DeletionStamp(c)
+
+		table.DeletedAt = &deletedAt
+		table.DeletedBy = deletedBy
+		table.UpdatedAt = deletedAt
+
+		if err := ctrl.tables.Update(ctx, table); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Table has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to delete table", err)
+			return
+		}
+
+		helper.SetETag(c, table.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Table deleted successfully", "table": table})
+	}
+}
+
+func (ctrl *TableController) RestoreTableByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+		defer cancel()
+
+		tableID := c.Param("tableId")
+
+		table, err := ctrl.tables.FindByID(repositories.WithDeleted(ctx), tableID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusNotFound, "Table not found")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to retrieve table", err)
+			return
+		}
+
+		if !helper.CheckIfMatch(c, table.Version) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Table has been modified; fetch it again and retry")
+			return
+		}
+
+		if table.DeletedAt == nil {
+			apierrors.Write(c, http.StatusConflict, "Table is not deleted")
+			return
+		}
+
+		table.DeletedAt = nil
+		table.DeletedBy = nil
+		table.UpdatedAt = time.Now().UTC()
+
+		if err := ctrl.tables.Update(ctx, table); errors.Is(err, repositories.ErrVersionConflict) {
+			apierrors.Write(c, http.StatusPreconditionFailed, "Table has been modified; fetch it again and retry")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Failed to restore table", err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"message": "Table updated successfully", "table": updatedTable})
+		helper.SetETag(c, table.Version)
+		c.JSON(http.StatusOK, gin.H{"message": "Table restored successfully", "table": table})
 	}
 }
@@ -0,0 +1,69 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/gin-gonic/gin"
+)
+
+type tableListResponse struct {
+	helper.ListResponse
+	Items []models.Table `json:"items"`
+}
+
+func TestTablePagination(t *testing.T) {
+	s := newTestServer(t)
+	token := s.adminToken()
+
+	for number := 1; number <= 5; number++ {
+		res := s.do(request{method: http.MethodPost, path: "/api/v1/tables/", token: token, body: gin.H{"tableNumber": number, "numberOfGuests": 4}})
+		expectStatus(t, res, http.StatusOK)
+	}
+
+	res := s.do(request{method: http.MethodGet, path: "/api/v1/tables/?page=1&limit=2&sort=tableNumber", token: token})
+	expectStatus(t, res, http.StatusOK)
+
+	var first tableListResponse
+	decode(t, res, &first)
+	if first.Total != 5 || len(first.Items) != 2 || *first.Items[0].TableNumber != 1 {
+		t.Fatalf("unexpected first page: %s", res.Body.String())
+	}
+	if first.Links.Next == nil || first.Links.Prev != nil {
+		t.Fatalf("expected only a next link on the first page: %+v", first.Links)
+	}
+
+	res = s.do(request{method: http.MethodGet, path: "/api/v1/tables/?page=3&limit=2&sort=tableNumber", token: token})
+	expectStatus(t, res, http.StatusOK)
+
+	var last tableListResponse
+	decode(t, res, &last)
+	if len(last.Items) != 1 || *last.Items[0].TableNumber != 5 {
+		t.Fatalf("unexpected last page: %s", res.Body.String())
+	}
+	if last.Links.Next != nil || last.Links.Prev == nil {
+		t.Fatalf("expected only a prev link on the last page: %+v", last.Links)
+	}
+
+	res = s.do(request{method: http.MethodGet, path: "/api/v1/tables/?numberOfGuests[gte]=4&sort=-tableNumber&limit=1", token: token})
+	expectStatus(t, res, http.StatusOK)
+
+	var filtered tableListResponse
+	decode(t, res, &filtered)
+	if filtered.Total != 5 || *filtered.Items[0].TableNumber != 5 {
+		t.Fatalf("unexpected filtered page: %s", res.Body.String())
+	}
+}
+
+func TestTableListRejectsUnknownFields(t *testing.T) {
+	s := newTestServer(t)
+	token := s.adminToken()
+
+	res := s.do(request{method: http.MethodGet, path: "/api/v1/tables/?sort=secret", token: token})
+	expectStatus(t, res, http.StatusBadRequest)
+
+	res = s.do(request{method: http.MethodGet, path: "/api/v1/tables/?secret=1", token: token})
+	expectStatus(t, res, http.StatusBadRequest)
+}
@@ -2,153 +2,244 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
-	"github.com/datarohit/go-restaurant-management-backend-project/database"
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
 	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
 	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
 )
 
-var userCollection *mongo.Collection = database.OpenCollection(database.Client, "user")
-var validate = validator.New()
+type UserController struct {
+	users                repositories.UserRepository
+	tokens               *helper.TokenManager
+	lockout              *helper.LoginLockout
+	accounts             *helper.AccountMailer
+	requireVerifiedEmail bool
+}
+
+func NewUserController(users repositories.UserRepository, tokens *helper.TokenManager, lockout *helper.LoginLockout, accounts *helper.AccountMailer, requireVerifiedEmail bool) *UserController {
+	return &UserController{users: users, tokens: tokens, lockout: lockout, accounts: accounts, requireVerifiedEmail: requireVerifiedEmail}
+}
 
-func SignUp() gin.HandlerFunc {
+func (ctrl *UserController) SignUp() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
-		var user models.User
-		if err := c.ShouldBindJSON(&user); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		var request models.SignUpRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			apierrors.InvalidBody(c, err)
 			return
 		}
 
-		if validationErr := validate.Struct(user); validationErr != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error()})
+		if validationErr := validate.Struct(request); validationErr != nil {
+			apierrors.Validation(c, validationErr)
 			return
 		}
 
-		if exists, err := helper.CheckDuplicateFields(ctx, user.Email, user.Phone); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error checking user existence"})
+		if exists, err := ctrl.users.ExistsByEmailOrPhone(ctx, request.Email, request.Phone); err != nil {
+			apierrors.Internal(c, "Error checking user existence", err)
 			return
 		} else if exists {
+			apierrors.Write(c, http.StatusConflict, "User with this email or phone already exists")
 			return
 		}
 
-		user.Password = helper.HashPassword(user.Password)
-		user.ID = primitive.NewObjectID()
+		hashedPassword, err := helper.HashPassword(&request.Password)
+		if err != nil {
+			apierrors.Internal(c, "Error hashing password", err)
+			return
+		}
+
+		role := models.UserRoleStaff
+		user := models.User{
+			ID:        primitive.NewObjectID(),
+			FirstName: &request.FirstName,
+			LastName:  &request.LastName,
+			Password:  hashedPassword,
+			Email:     &request.Email,
+			Avatar:    request.Avatar,
+			Phone:     &request.Phone,
+			Role:      &role,
+			CreatedAt: time.Now().UTC(),
+		}
 		user.UserID = user.ID.Hex()
-		user.CreatedAt = time.Now().UTC()
 		user.UpdatedAt = user.CreatedAt
 
-		accessToken, refreshToken, err := helper.GenerateAllTokens(*user.Email, *user.FirstName, *user.LastName, user.UserID)
+		accessToken, refreshToken, err := ctrl.tokens.IssueTokens(user)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating tokens"})
+			apierrors.Internal(c, "Error generating tokens", err)
 			return
 		}
 
-		user.AccessToken = &accessToken
-		user.RefreshToken = &refreshToken
+		accessTokenHash, refreshTokenHash := helper.HashToken(accessToken), helper.HashToken(refreshToken)
+		user.AccessTokenHash = &accessTokenHash
+		user.RefreshTokenHash = &refreshTokenHash
 
-		if _, err := userCollection.InsertOne(ctx, user); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating user"})
+		if err := ctrl.users.Create(ctx, &user); errors.Is(err, repositories.ErrDuplicate) {
+			apierrors.Write(c, http.StatusConflict, "User with this email or phone already exists")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Error creating user", err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"message": "User created successfully", "user": user})
+		if err := ctrl.accounts.SendEmailVerification(ctx, user); err != nil {
+			utils.LoggerFrom(ctx).Error("Failed to send verification email", zap.String("userId", user.UserID), zap.Error(err))
+		}
+
+		c.JSON(http.StatusOK, models.AuthResponse{
+			Message:      "User created successfully",
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+			User:         models.NewUserResponse(user),
+		})
 	}
 }
 
-func Login() gin.HandlerFunc {
+func (ctrl *UserController) Login() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
-		var user models.User
-		if err := c.ShouldBindJSON(&user); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		var request models.LoginRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			apierrors.InvalidBody(c, err)
+			return
+		}
+
+		if validationErr := validate.Struct(request); validationErr != nil {
+			apierrors.Validation(c, validationErr)
+			return
+		}
+
+		foundUser, err := ctrl.users.FindByEmail(ctx, request.Email)
+		if err != nil {
+			apierrors.Write(c, http.StatusUnauthorized, "Invalid email or password")
 			return
 		}
 
-		var foundUser models.User
-		if err := helper.FindUserByEmail(ctx, user.Email, &foundUser); err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+		if lockedFor := ctrl.lockout.LockedFor(*foundUser, time.Now().UTC()); lockedFor > 0 {
+			problem := apierrors.New(http.StatusTooManyRequests, "Too many failed login attempts; the account is temporarily locked").WithCode("account_locked")
+			apierrors.RetryLater(c, problem, lockedFor)
 			return
 		}
 
-		passwordIsValid, msg := helper.VerifyPassword(*foundUser.Password, *user.Password)
+		passwordIsValid, msg := helper.VerifyPassword(*foundUser.Password, request.Password)
 		if !passwordIsValid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": msg})
+			lockedFor, err := ctrl.lockout.RecordFailure(ctx, *foundUser)
+			if err != nil {
+				apierrors.Internal(c, "Error recording failed login", err)
+				return
+			}
+			if lockedFor > 0 {
+				utils.LoggerFrom(ctx).Warn("Account locked after repeated failed logins",
+					zap.String("userId", foundUser.UserID),
+					zap.Duration("lockedFor", lockedFor))
+			}
+			apierrors.Write(c, http.StatusUnauthorized, msg)
+			return
+		}
+
+		if foundUser.Deactivated {
+			apierrors.New(http.StatusForbidden, "This account has been deactivated").WithCode("account_deactivated").Send(c)
+			return
+		}
+
+		if ctrl.requireVerifiedEmail && !foundUser.EmailVerified {
+			apierrors.New(http.StatusForbidden, "Verify your email address before logging in").WithCode("email_not_verified").Send(c)
+			return
+		}
+
+		if err := ctrl.lockout.RecordSuccess(ctx, *foundUser); err != nil {
+			apierrors.Internal(c, "Error resetting failed logins", err)
 			return
 		}
 
-		accessToken, refreshToken, err := helper.GetOrGenerateTokens(foundUser)
+		accessToken, refreshToken, err := ctrl.tokens.IssueTokens(*foundUser)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating tokens"})
+			apierrors.Internal(c, "Error generating tokens", err)
 			return
 		}
 
-		if err := helper.UpdateAllTokens(accessToken, refreshToken, foundUser.UserID); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating tokens"})
+		if err := helper.UpdateAllTokens(ctx, ctrl.users, accessToken, refreshToken, foundUser.UserID); err != nil {
+			apierrors.Internal(c, "Error updating tokens", err)
 			return
 		}
 
-		if err := helper.FindUserByEmail(ctx, user.Email, &foundUser); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error retrieving updated user"})
+		foundUser, err = ctrl.users.FindByID(ctx, foundUser.UserID)
+		if err != nil {
+			apierrors.Internal(c, "Error retrieving updated user", err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"message": "User logged in successfully",
-			"user":    foundUser,
+		c.JSON(http.StatusOK, models.AuthResponse{
+			Message:      "User logged in successfully",
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+			User:         models.NewUserResponse(*foundUser),
 		})
 	}
 }
 
-func GetUserByID() gin.HandlerFunc {
+func (ctrl *UserController) GetUserByID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 		defer cancel()
 
 		userId := c.Param("userId")
 		if userId == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "userId is required"})
+			apierrors.Write(c, http.StatusBadRequest, "userId is required")
 			return
 		}
 
-		var user models.User
-		if err := helper.FindUserByID(ctx, userId, &user); err != nil {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		user, err := ctrl.users.FindByID(ctx, userId)
+		if err != nil {
+			apierrors.Write(c, http.StatusNotFound, "User not found")
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"user": user})
+		c.JSON(http.StatusOK, gin.H{"user": models.NewUserResponse(*user)})
 	}
 }
 
-func GetAllUsers() gin.HandlerFunc {
+var userListSpec = helper.ListSpec{
+	Filters: map[string]helper.FieldType{
+		"firstName": helper.StringField,
+		"lastName":  helper.StringField,
+		"email":     helper.StringField,
+		"role":      helper.StringField,
+		"createdAt": helper.TimeField,
+	},
+	Sorts:       []string{"firstName", "lastName", "email", "createdAt"},
+	DefaultSort: "-createdAt",
+}
+
+func (ctrl *UserController) GetAllUsers() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Second)
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 100*time.Second)
 		defer cancel()
 
-		recordPerPage, page := helper.GetPaginationParams(c)
+		query, err := helper.ParseListQuery(c, userListSpec)
+		if err != nil {
+			apierrors.Write(c, http.StatusBadRequest, err.Error())
+			return
+		}
 
-		skip := (page - 1) * recordPerPage
-		users, totalCount, err := helper.GetPaginatedUsers(ctx, skip, recordPerPage)
+		users, total, err := ctrl.users.List(ctx, query)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve users"})
+			apierrors.Internal(c, "Failed to retrieve users", err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"totalCount": totalCount,
-			"userItems":  users,
-		})
+		c.JSON(http.StatusOK, helper.NewListResponse(c, models.NewUserResponses(users), total, query))
 	}
 }
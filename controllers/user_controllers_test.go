@@ -0,0 +1,174 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/gin-gonic/gin"
+)
+
+func TestSignUpAndLogin(t *testing.T) {
+	s := newTestServer(t)
+
+	signup := gin.H{"firstName": "Jane", "lastName": "Doe", "email": "jane@example.com", "password": "secret1", "phone": "555-0100"}
+	res := s.do(request{method: http.MethodPost, path: "/api/v1/users/signup", body: signup})
+	expectStatus(t, res, http.StatusOK)
+
+	var auth models.AuthResponse
+	decode(t, res, &auth)
+	if auth.AccessToken == "" || auth.RefreshToken == "" {
+		t.Fatalf("expected tokens in the signup response, got %+v", auth)
+	}
+	if auth.User.Email == nil || *auth.User.Email != "jane@example.com" {
+		t.Fatalf("unexpected user in the signup response: %s", res.Body.String())
+	}
+
+	res = s.do(request{method: http.MethodPost, path: "/api/v1/users/signup", body: signup})
+	expectStatus(t, res, http.StatusConflict)
+
+	res = s.do(request{method: http.MethodPost, path: "/api/v1/users/login", body: gin.H{"email": "jane@example.com", "password": "wrong"}})
+	expectStatus(t, res, http.StatusUnauthorized)
+
+	token := s.login("jane@example.com", "secret1")
+	res = s.do(request{method: http.MethodGet, path: "/api/v1/users/me", token: token})
+	expectStatus(t, res, http.StatusOK)
+}
+
+func TestAuthenticationRequiresAToken(t *testing.T) {
+	s := newTestServer(t)
+
+	res := s.do(request{method: http.MethodGet, path: "/api/v1/users/me"})
+	expectStatus(t, res, http.StatusUnauthorized)
+
+	res = s.do(request{method: http.MethodGet, path: "/api/v1/users/me", token: "not-a-token"})
+	expectStatus(t, res, http.StatusUnauthorized)
+}
+
+func TestLoginReplacesEarlierTokens(t *testing.T) {
+	s := newTestServer(t)
+	s.createUser("staff@example.com", "secret1", models.UserRoleStaff)
+
+	first := s.login("staff@example.com", "secret1")
+	time.Sleep(2 * time.Millisecond)
+	second := s.login("staff@example.com", "secret1")
+
+	res := s.do(request{method: http.MethodGet, path: "/api/v1/users/me", token: first})
+	expectStatus(t, res, http.StatusUnauthorized)
+	expectCode(t, res, "token_revoked")
+
+	res = s.do(request{method: http.MethodGet, path: "/api/v1/users/me", token: second})
+	expectStatus(t, res, http.StatusOK)
+}
+
+func TestChangePasswordRevokesTokens(t *testing.T) {
+	s := newTestServer(t)
+	s.createUser("staff@example.com", "secret1", models.UserRoleStaff)
+	token := s.login("staff@example.com", "secret1")
+
+	res := s.do(request{method: http.MethodPut, path: "/api/v1/users/me/password", token: token,
+		body: gin.H{"currentPassword": "wrong", "newPassword": "secret2"}})
+	expectStatus(t, res, http.StatusBadRequest)
+	expectCode(t, res, "incorrect_password")
+
+	res = s.do(request{method: http.MethodPut, path: "/api/v1/users/me/password", token: token,
+		body: gin.H{"currentPassword": "secret1", "newPassword": "secret2"}})
+	expectStatus(t, res, http.StatusOK)
+
+	var auth models.AuthResponse
+	decode(t, res, &auth)
+
+	res = s.do(request{method: http.MethodGet, path: "/api/v1/users/me", token: token})
+	expectStatus(t, res, http.StatusUnauthorized)
+
+	res = s.do(request{method: http.MethodGet, path: "/api/v1/users/me", token: auth.AccessToken})
+	expectStatus(t, res, http.StatusOK)
+
+	res = s.do(request{method: http.MethodPost, path: "/api/v1/users/login", body: gin.H{"email": "staff@example.com", "password": "secret1"}})
+	expectStatus(t, res, http.StatusUnauthorized)
+	s.login("staff@example.com", "secret2")
+}
+
+func TestDeactivatedUsersAreRejected(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.adminToken()
+	staffID := s.createUser("staff@example.com", "secret1", models.UserRoleStaff)
+	staff := s.login("staff@example.com", "secret1")
+
+	res := s.do(request{method: http.MethodPost, path: "/api/v1/users/" + staffID + "/deactivate", token: staff})
+	expectStatus(t, res, http.StatusForbidden)
+
+	res = s.do(request{method: http.MethodPost, path: "/api/v1/users/" + staffID + "/deactivate", token: admin})
+	expectStatus(t, res, http.StatusOK)
+
+	res = s.do(request{method: http.MethodGet, path: "/api/v1/users/me", token: staff})
+	expectStatus(t, res, http.StatusUnauthorized)
+	expectCode(t, res, "account_deactivated")
+
+	res = s.do(request{method: http.MethodPost, path: "/api/v1/users/login", body: gin.H{"email": "staff@example.com", "password": "secret1"}})
+	expectStatus(t, res, http.StatusForbidden)
+	expectCode(t, res, "account_deactivated")
+}
+
+func TestRepeatedFailedLoginsLockTheAccount(t *testing.T) {
+	s := newTestServer(t)
+	s.createUser("staff@example.com", "secret1", models.UserRoleStaff)
+
+	for i := 0; i < 5; i++ {
+		res := s.do(request{method: http.MethodPost, path: "/api/v1/users/login", body: gin.H{"email": "staff@example.com", "password": "wrong"}})
+		expectStatus(t, res, http.StatusUnauthorized)
+	}
+
+	res := s.do(request{method: http.MethodPost, path: "/api/v1/users/login", body: gin.H{"email": "staff@example.com", "password": "secret1"}})
+	expectStatus(t, res, http.StatusTooManyRequests)
+	expectCode(t, res, "account_locked")
+	if res.Header().Get("Retry-After") == "" {
+		t.Fatal("expected a Retry-After header on a locked account")
+	}
+}
+
+func TestDemotedAdminsLoseAdminRoutes(t *testing.T) {
+	s := newTestServer(t)
+	admin := s.adminToken()
+	staffID := s.createUser("staff@example.com", "secret1", models.UserRoleStaff)
+
+	ctx := context.Background()
+	user, err := s.repos.Users.FindByEmail(ctx, "admin@example.com")
+	if err != nil {
+		t.Fatalf("loading admin: %v", err)
+	}
+	role := models.UserRoleStaff
+	user.Role = &role
+	if err := s.repos.Users.Update(ctx, user); err != nil {
+		t.Fatalf("demoting admin: %v", err)
+	}
+
+	res := s.do(request{method: http.MethodPost, path: "/api/v1/users/" + staffID + "/deactivate", token: admin})
+	expectStatus(t, res, http.StatusForbidden)
+}
+
+func TestUpdatePhoneChecksOtherUsersOnly(t *testing.T) {
+	s := newTestServer(t)
+	s.createUser("other@example.com", "secret1", models.UserRoleStaff)
+	s.createUser("staff@example.com", "secret1", models.UserRoleStaff)
+	token := s.login("staff@example.com", "secret1")
+
+	ctx := context.Background()
+	other, err := s.repos.Users.FindByEmail(ctx, "other@example.com")
+	if err != nil {
+		t.Fatalf("loading user: %v", err)
+	}
+	empty := ""
+	other.Email = &empty
+	if err := s.repos.Users.Update(ctx, other); err != nil {
+		t.Fatalf("clearing email: %v", err)
+	}
+
+	res := s.do(request{method: http.MethodPatch, path: "/api/v1/users/me", token: token, body: gin.H{"phone": "555-0199"}})
+	expectStatus(t, res, http.StatusOK)
+
+	res = s.do(request{method: http.MethodPatch, path: "/api/v1/users/me", token: token, body: gin.H{"phone": "other@example.com"}})
+	expectStatus(t, res, http.StatusConflict)
+}
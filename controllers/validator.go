@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(apierrors.JSONTagName)
+
+	_ = v.RegisterValidation("allergen", func(fl validator.FieldLevel) bool {
+		return models.IsValidAllergen(fl.Field().String())
+	})
+	_ = v.RegisterValidation("dietaryflag", func(fl validator.FieldLevel) bool {
+		return models.IsValidDietaryFlag(fl.Field().String())
+	})
+
+	v.RegisterStructValidation(promotionStructLevel, models.Promotion{})
+
+	return v
+}
+
+func promotionStructLevel(sl validator.StructLevel) {
+	promotion := sl.Current().Interface().(models.Promotion)
+	if promotion.Type == nil || *promotion.Type != models.PromotionTypePercentage || promotion.DiscountValue == nil {
+		return
+	}
+	if *promotion.DiscountValue > 100 {
+		sl.ReportError(*promotion.DiscountValue, "discountValue", "DiscountValue", "max", "100")
+	}
+}
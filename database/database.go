@@ -2,61 +2,242 @@ package database
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/datarohit/go-restaurant-management-backend-project/config"
+	"github.com/datarohit/go-restaurant-management-backend-project/metrics"
 	"github.com/datarohit/go-restaurant-management-backend-project/utils"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 	"go.uber.org/zap"
 )
 
-func DBinstance() *mongo.Client {
-	log := utils.GetLogger()
+type State string
 
-	mongoURI := config.GetEnv("MONGODB_URI", "mongodb://localhost:27017")
-	connectTimeout := config.GetEnvAsInt("MONGODB_CONNECT_TIMEOUT", 10)
+const (
+	StateDisconnected State = "disconnected"
+	StateConnecting   State = "connecting"
+	StateConnected    State = "connected"
+	StateFailed       State = "failed"
+)
 
-	log.Info("Connecting to MongoDB",
-		zap.String("uri", mongoURI),
-		zap.Int("timeout", connectTimeout))
+var ErrNotConnected = errors.New("database client is not connected")
 
-	clientOptions := options.Client().ApplyURI(mongoURI)
+var Client *mongo.Client
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(connectTimeout)*time.Second)
-	defer cancel()
+var (
+	mu        sync.RWMutex
+	state     = StateDisconnected
+	lastError error
+	ready     = make(chan struct{})
+	readyOnce sync.Once
+	stopRetry context.CancelFunc
+)
 
-	client, err := mongo.Connect(ctx, clientOptions)
-	if err != nil {
-		log.Error("Error connecting to MongoDB", zap.Error(err))
-		return nil
+type retryConfig struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	pingTimeout    time.Duration
+}
+
+func clientOptions(cfg config.MongoConfig) *options.ClientOptions {
+	return options.Client().
+		ApplyURI(cfg.URI).
+		SetConnectTimeout(cfg.ConnectTimeout).
+		SetServerSelectionTimeout(cfg.ServerSelectionTimeout).
+		SetMaxPoolSize(cfg.MaxPoolSize).
+		SetMinPoolSize(cfg.MinPoolSize).
+		SetMaxConnIdleTime(cfg.MaxConnIdleTime).
+		SetMonitor(combineMonitors(otelmongo.NewMonitor(), metrics.CommandMonitor())).
+		SetPoolMonitor(metrics.PoolMonitor())
+}
+
+func combineMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			for _, monitor := range monitors {
+				if monitor.Started != nil {
+					monitor.Started(ctx, e)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			for _, monitor := range monitors {
+				if monitor.Succeeded != nil {
+					monitor.Succeeded(ctx, e)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			for _, monitor := range monitors {
+				if monitor.Failed != nil {
+					monitor.Failed(ctx, e)
+				}
+			}
+		},
 	}
+}
 
-	err = client.Ping(ctx, nil)
+func loadRetryConfig(cfg config.MongoConfig) retryConfig {
+	return retryConfig{
+		maxAttempts:    cfg.ConnectRetries,
+		initialBackoff: cfg.RetryInitialBackoff,
+		maxBackoff:     cfg.RetryMaxBackoff,
+		pingTimeout:    cfg.ConnectTimeout,
+	}
+}
+
+func Connect(cfg config.MongoConfig) (*mongo.Client, error) {
+	log := utils.GetLogger()
+
+	opts := clientOptions(cfg)
+	log.Info("Creating MongoDB client",
+		zap.String("uri", cfg.URI),
+		zap.Uint64p("maxPoolSize", opts.MaxPoolSize),
+		zap.Uint64p("minPoolSize", opts.MinPoolSize))
+
+	client, err := mongo.Connect(context.Background(), opts)
 	if err != nil {
-		log.Error("MongoDB ping failed", zap.Error(err))
+		setState(StateFailed, err)
+		log.Error("Error creating MongoDB client", zap.Error(err))
+		return nil, err
+	}
+
+	Client = client
+	setState(StateConnecting, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mu.Lock()
+	stopRetry = cancel
+	mu.Unlock()
+
+	go waitForServer(ctx, client, loadRetryConfig(cfg))
+
+	return client, nil
+}
+
+func waitForServer(ctx context.Context, client *mongo.Client, cfg retryConfig) {
+	log := utils.GetLogger()
+	backoff := cfg.initialBackoff
+
+	for attempt := 1; ; attempt++ {
+		pingCtx, cancel := context.WithTimeout(ctx, cfg.pingTimeout)
+		err := client.Ping(pingCtx, readpref.Primary())
+		cancel()
+
+		if err == nil {
+			markConnected()
+			log.Info("Successfully connected to MongoDB", zap.Int("attempt", attempt))
+			return
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if cfg.maxAttempts > 0 && attempt >= cfg.maxAttempts {
+			if attempt == cfg.maxAttempts {
+				log.Error("MongoDB is still unreachable after the configured retries; retrying in the background",
+					zap.Int("attempts", attempt),
+					zap.Duration("backoff", cfg.maxBackoff),
+					zap.Error(err))
+			}
+			setState(StateFailed, err)
+		} else {
+			setState(StateConnecting, err)
+			log.Warn("MongoDB ping failed, retrying",
+				zap.Int("attempt", attempt),
+				zap.Duration("backoff", backoff),
+				zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > cfg.maxBackoff {
+			backoff = cfg.maxBackoff
+		}
+	}
+}
+
+func WaitUntilReady(ctx context.Context) error {
+	select {
+	case <-ready:
 		return nil
+	case <-ctx.Done():
+		if _, err := Status(); err != nil {
+			return err
+		}
+		return ctx.Err()
 	}
+}
 
-	log.Info("Successfully connected to MongoDB")
-	return client
+func Status() (State, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	return state, lastError
 }
 
-var Client *mongo.Client = DBinstance()
+func Check(ctx context.Context) error {
+	state, err := Status()
+	if state == StateFailed {
+		if pingErr := Ping(ctx); pingErr == nil {
+			markConnected()
+			return nil
+		}
+	}
 
-func OpenCollection(client *mongo.Client, collectionName string) *mongo.Collection {
-	log := utils.GetLogger()
+	if state != StateConnected {
+		if err != nil {
+			return fmt.Errorf("database is %s: %w", state, err)
+		}
+		return fmt.Errorf("database is %s", state)
+	}
+	return Ping(ctx)
+}
 
-	databaseName := config.GetEnv("MONGODB_DATABASE", "restaurant")
-	log.Info("Opening MongoDB collection",
-		zap.String("database", databaseName),
-		zap.String("collection", collectionName))
+func Ping(ctx context.Context) error {
+	if Client == nil {
+		return ErrNotConnected
+	}
+	return Client.Ping(ctx, readpref.Primary())
+}
 
-	collection := client.Database(databaseName).Collection(collectionName)
-	if collection == nil {
-		log.Error("Failed to open MongoDB collection", zap.String("collection", collectionName))
+func Disconnect(ctx context.Context) error {
+	mu.Lock()
+	if stopRetry != nil {
+		stopRetry()
+	}
+	mu.Unlock()
+
+	if Client == nil {
 		return nil
 	}
 
-	return collection
+	err := Client.Disconnect(ctx)
+	setState(StateDisconnected, err)
+	return err
+}
+
+func markConnected() {
+	setState(StateConnected, nil)
+	readyOnce.Do(func() { close(ready) })
+}
+
+func setState(newState State, err error) {
+	mu.Lock()
+	defer mu.Unlock()
+	state = newState
+	lastError = err
 }
@@ -0,0 +1,92 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/migrations"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func MigrationsCheck(db *mongo.Database) CheckFunc {
+	return func(ctx context.Context) error {
+		statuses, err := migrations.Status(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		pending := 0
+		for _, status := range statuses {
+			if !status.Applied {
+				pending++
+			}
+		}
+		if pending > 0 {
+			return fmt.Errorf("%d migration(s) pending", pending)
+		}
+		return nil
+	}
+}
+
+func DiskSpaceCheck(path string, minFreeBytes uint64) CheckFunc {
+	return func(ctx context.Context) error {
+		dir, err := existingDir(path)
+		if err != nil {
+			return err
+		}
+
+		free, err := freeBytes(dir)
+		if err != nil {
+			return err
+		}
+		if free < minFreeBytes {
+			return fmt.Errorf("only %d MB free on %s (need %d MB)", free>>20, dir, minFreeBytes>>20)
+		}
+		return nil
+	}
+}
+
+func existingDir(path string) (string, error) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no existing directory for %s", path)
+		}
+		dir = parent
+	}
+}
+
+type Heartbeat struct {
+	last atomic.Int64
+}
+
+func (r *Registry) Heartbeat(name string, maxAge time.Duration) *Heartbeat {
+	heartbeat := &Heartbeat{}
+	heartbeat.Beat()
+	r.Register(name, Liveness, func(ctx context.Context) error {
+		if age := time.Since(heartbeat.LastBeat()); age > maxAge {
+			return fmt.Errorf("no heartbeat for %s", age.Round(time.Millisecond))
+		}
+		return nil
+	})
+	return heartbeat
+}
+
+func (h *Heartbeat) Beat() {
+	h.last.Store(time.Now().UnixNano())
+}
+
+func (h *Heartbeat) LastBeat() time.Time {
+	return time.Unix(0, h.last.Load())
+}
@@ -0,0 +1,134 @@
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Kind int
+
+const (
+	Readiness Kind = iota
+	Liveness
+)
+
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+)
+
+type CheckFunc func(ctx context.Context) error
+
+type Result struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+type check struct {
+	name string
+	kind Kind
+	run  CheckFunc
+}
+
+type Registry struct {
+	mu           sync.RWMutex
+	checks       []check
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{timeout: timeout}
+}
+
+func (r *Registry) Register(name string, kind Kind, run CheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, check{name: name, kind: kind, run: run})
+}
+
+func (r *Registry) SetShuttingDown() {
+	r.shuttingDown.Store(true)
+}
+
+func (r *Registry) ShuttingDown() bool {
+	return r.shuttingDown.Load()
+}
+
+func (r *Registry) Live(ctx context.Context) Report {
+	return r.run(ctx, Liveness)
+}
+
+func (r *Registry) Ready(ctx context.Context) Report {
+	report := r.run(ctx, Readiness)
+	if r.ShuttingDown() {
+		report.Status = StatusFail
+		report.Checks = append(report.Checks, Result{Name: "shutdown", Status: StatusFail, Error: "server is shutting down"})
+	}
+	return report
+}
+
+func (r *Registry) run(ctx context.Context, kind Kind) Report {
+	r.mu.RLock()
+	var selected []check
+	for _, c := range r.checks {
+		if c.kind == kind {
+			selected = append(selected, c)
+		}
+	}
+	r.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	results := make([]Result, len(selected))
+	var wg sync.WaitGroup
+	for i, c := range selected {
+		wg.Add(1)
+		go func(i int, c check) {
+			defer wg.Done()
+			results[i] = runCheck(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	report := Report{Status: StatusPass, Checks: results}
+	for _, result := range results {
+		if result.Status != StatusPass {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+func runCheck(ctx context.Context, c check) Result {
+	start := time.Now()
+	errs := make(chan error, 1)
+	go func() { errs <- c.run(ctx) }()
+
+	var err error
+	select {
+	case err = <-errs:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := Result{Name: c.name, Status: StatusPass, LatencyMs: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
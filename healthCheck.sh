@@ -1,14 +1,14 @@
 #!/bin/bash
 
-# Perform the first GET request
-if ! curl -f http://localhost:8080/health/router > /dev/null 2>&1; then
-    echo "Health check failed for /health/router"
+# Check that the process is alive
+if ! curl -f http://localhost:8080/health/live > /dev/null 2>&1; then
+    echo "Health check failed for /health/live"
     exit 1
 fi
 
-# Perform the second GET request
-if ! curl -f http://localhost:8080/health/database > /dev/null 2>&1; then
-    echo "Health check failed for /health/database"
+# Check that the dependencies are ready to serve traffic
+if ! curl -f http://localhost:8080/health/ready > /dev/null 2>&1; then
+    echo "Health check failed for /health/ready"
     exit 1
 fi
 
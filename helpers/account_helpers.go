@@ -0,0 +1,125 @@
+package helpers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/config"
+	"github.com/datarohit/go-restaurant-management-backend-project/mailer"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AccountMailer struct {
+	tokens          repositories.UserTokenRepository
+	mailer          mailer.Mailer
+	linkBaseURL     string
+	resetTTL        time.Duration
+	verificationTTL time.Duration
+}
+
+func NewAccountMailer(tokens repositories.UserTokenRepository, m mailer.Mailer, mailCfg config.MailConfig, authCfg config.AuthConfig) *AccountMailer {
+	return &AccountMailer{
+		tokens:          tokens,
+		mailer:          m,
+		linkBaseURL:     strings.TrimRight(mailCfg.LinkBaseURL, "/"),
+		resetTTL:        authCfg.PasswordResetTTL,
+		verificationTTL: authCfg.EmailVerificationTTL,
+	}
+}
+
+func (a *AccountMailer) SendEmailVerification(ctx context.Context, user models.User) error {
+	token, err := a.issue(ctx, user, models.UserTokenEmailVerification, a.verificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return a.mailer.Send(ctx, mailer.Message{
+		To:      *user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address by opening the link below:\n\n%s\n\nThe link expires in %s.\n",
+			GetNonNilString(user.FirstName, "there"), a.link("verify-email", token), formatTTL(a.verificationTTL)),
+	})
+}
+
+func (a *AccountMailer) SendPasswordReset(ctx context.Context, user models.User) error {
+	token, err := a.issue(ctx, user, models.UserTokenPasswordReset, a.resetTTL)
+	if err != nil {
+		return err
+	}
+
+	return a.mailer.Send(ctx, mailer.Message{
+		To:      *user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nSomeone asked to reset the password for your account. Open the link below to choose a new one:\n\n%s\n\nThe link expires in %s and can only be used once. If you did not ask for this, you can ignore this email.\n",
+			GetNonNilString(user.FirstName, "there"), a.link("reset-password", token), formatTTL(a.resetTTL)),
+	})
+}
+
+func (a *AccountMailer) Redeem(ctx context.Context, token, purpose string) (*models.UserToken, error) {
+	return a.tokens.Consume(ctx, HashToken(token), purpose, time.Now().UTC())
+}
+
+func (a *AccountMailer) issue(ctx context.Context, user models.User, purpose string, ttl time.Duration) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generating token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	now := time.Now().UTC()
+	if err := a.tokens.InvalidateForUser(ctx, user.UserID, purpose, now); err != nil {
+		return "", err
+	}
+
+	record := models.UserToken{
+		ID:        primitive.NewObjectID(),
+		TokenHash: HashToken(token),
+		UserID:    user.UserID,
+		Purpose:   purpose,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+	record.UserTokenID = record.ID.Hex()
+
+	if err := a.tokens.Create(ctx, &record); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (a *AccountMailer) link(path, token string) string {
+	return a.linkBaseURL + "/" + path + "?token=" + url.QueryEscape(token)
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func formatTTL(ttl time.Duration) string {
+	ttl = ttl.Round(time.Second)
+	hours := ttl / time.Hour
+	minutes := ttl % time.Hour / time.Minute
+	seconds := ttl % time.Minute / time.Second
+
+	var formatted strings.Builder
+	if hours > 0 {
+		fmt.Fprintf(&formatted, "%dh", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&formatted, "%dm", minutes)
+	}
+	if seconds > 0 || formatted.Len() == 0 {
+		fmt.Fprintf(&formatted, "%ds", seconds)
+	}
+	return formatted.String()
+}
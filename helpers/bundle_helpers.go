@@ -0,0 +1,84 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+)
+
+var ErrInvalidBundleSelection = errors.New("invalid bundle selection")
+
+func ValidateBundleComponents(components []models.BundleComponent) error {
+	seen := make(map[string]bool, len(components))
+	for _, component := range components {
+		if seen[component.ComponentID] {
+			return fmt.Errorf("duplicate componentId: %s", component.ComponentID)
+		}
+		seen[component.ComponentID] = true
+
+		if component.Type == models.BundleComponentFixed && len(component.FoodIDs) != 1 {
+			return fmt.Errorf("fixed component %q must reference exactly one food", component.Name)
+		}
+	}
+	return nil
+}
+
+func ExpandBundle(ctx context.Context, foodRepository repositories.FoodRepository, bundle models.Bundle, choices map[string]string) ([]models.OrderItemComponent, []string, error) {
+	selected := make([]string, 0, len(bundle.Components))
+	for _, component := range bundle.Components {
+		if component.Type == models.BundleComponentFixed {
+			selected = append(selected, component.FoodIDs[0])
+			continue
+		}
+
+		choice, ok := choices[component.ComponentID]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: a choice is required for component %q", ErrInvalidBundleSelection, component.ComponentID)
+		}
+
+		allowed := false
+		for _, foodID := range component.FoodIDs {
+			if foodID == choice {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, nil, fmt.Errorf("%w: food %s is not an option for component %q", ErrInvalidBundleSelection, choice, component.ComponentID)
+		}
+		selected = append(selected, choice)
+	}
+
+	foods, err := foodRepository.FindByIDs(ctx, selected)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	foodsByID := make(map[string]models.Food, len(foods))
+	for _, food := range foods {
+		foodsByID[food.FoodID] = food
+	}
+
+	components := make([]models.OrderItemComponent, 0, len(bundle.Components))
+	var allergens []string
+	for i, component := range bundle.Components {
+		food, ok := foodsByID[selected[i]]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: food %s no longer exists", ErrInvalidBundleSelection, selected[i])
+		}
+
+		components = append(components, models.OrderItemComponent{
+			ComponentID: component.ComponentID,
+			Name:        component.Name,
+			FoodID:      food.FoodID,
+			FoodName:    GetNonNilString(food.Name, ""),
+			Allergens:   food.Allergens,
+		})
+		allergens = append(allergens, food.Allergens...)
+	}
+
+	return components, NormalizeFoodLabels(allergens), nil
+}
@@ -1,34 +1,50 @@
 package helpers
 
 import (
-	"context"
+	"fmt"
+	"strings"
 
 	"github.com/datarohit/go-restaurant-management-backend-project/models"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/gin-gonic/gin"
 )
 
-func GetPaginatedFoodItems(ctx context.Context, skip int64, recordPerPage int64) ([]models.Food, int64, error) {
-	pipeline := mongo.Pipeline{
-		bson.D{{Key: "$skip", Value: skip}},
-		bson.D{{Key: "$limit", Value: recordPerPage}},
+func GetFoodFilterParams(c *gin.Context) (repositories.FoodFilter, error) {
+	excludeAllergens := GetQueryList(c, "excludeAllergens")
+	for _, allergen := range excludeAllergens {
+		if !models.IsValidAllergen(allergen) {
+			return repositories.FoodFilter{}, fmt.Errorf("unknown allergen: %s", allergen)
+		}
 	}
 
-	cursor, err := foodCollection.Aggregate(ctx, pipeline)
-	if err != nil {
-		return nil, 0, err
+	dietaryFlags := GetQueryList(c, "dietary")
+	for _, flag := range dietaryFlags {
+		if !models.IsValidDietaryFlag(flag) {
+			return repositories.FoodFilter{}, fmt.Errorf("unknown dietary flag: %s", flag)
+		}
 	}
-	defer cursor.Close(ctx)
 
-	var foodItems []models.Food
-	if err := cursor.All(ctx, &foodItems); err != nil {
-		return nil, 0, err
+	return repositories.FoodFilter{
+		ExcludeAllergens: excludeAllergens,
+		DietaryFlags:     dietaryFlags,
+	}, nil
+}
+
+func NormalizeFoodLabels(labels []string) []string {
+	if labels == nil {
+		return nil
 	}
 
-	totalCount, err := foodCollection.CountDocuments(ctx, bson.D{})
-	if err != nil {
-		return nil, 0, err
+	normalized := make([]string, 0, len(labels))
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		label = strings.ToUpper(strings.TrimSpace(label))
+		if label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		normalized = append(normalized, label)
 	}
 
-	return foodItems, totalCount, nil
+	return normalized
 }
@@ -1,29 +1,13 @@
 package helpers
 
 import (
-	"context"
 	"math"
-	"strconv"
+	"strings"
 	"time"
 
-	"github.com/datarohit/go-restaurant-management-backend-project/database"
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
-var (
-	userCollection      *mongo.Collection = database.OpenCollection(database.Client, "user")
-	foodCollection      *mongo.Collection = database.OpenCollection(database.Client, "food")
-	orderCollection     *mongo.Collection = database.OpenCollection(database.Client, "order")
-	orderItemCollection *mongo.Collection = database.OpenCollection(database.Client, "orderItem")
-)
-
-func RecordExists(ctx context.Context, collection *mongo.Collection, field string, value interface{}) (bool, error) {
-	count, err := collection.CountDocuments(ctx, bson.M{field: value})
-	return count > 0, err
-}
-
 func GetNonNilString(s *string, defaultValue string) string {
 	if s == nil || *s == "" {
 		return defaultValue
@@ -31,18 +15,17 @@ func GetNonNilString(s *string, defaultValue string) string {
 	return *s
 }
 
-func GetPaginationParams(c *gin.Context) (int64, int64) {
-	recordPerPage, err := strconv.ParseInt(c.Query("recordPerPage"), 10, 64)
-	if err != nil || recordPerPage < 1 {
-		recordPerPage = 10
+func GetQueryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(key) {
+		for _, value := range strings.Split(raw, ",") {
+			value = strings.ToUpper(strings.TrimSpace(value))
+			if value != "" {
+				values = append(values, value)
+			}
+		}
 	}
-
-	page, err := strconv.ParseInt(c.Query("page"), 10, 64)
-	if err != nil || page < 1 {
-		page = 1
-	}
-
-	return recordPerPage, page
+	return values
 }
 
 func InTimeSpan(start, end, check time.Time) bool {
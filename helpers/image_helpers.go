@@ -0,0 +1,127 @@
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+)
+
+var AllowedImageTypes = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+const (
+	MaxImageDimension = 8000
+	MaxImagePixels    = 40_000_000
+)
+
+var ThumbnailSizes = map[string]int{
+	"small":  150,
+	"medium": 600,
+}
+
+type ProcessedImage struct {
+	ContentType string
+	Extension   string
+	Original    []byte
+	Thumbnails  map[string][]byte
+}
+
+func ProcessImage(data []byte, maxSize int64) (*ProcessedImage, error) {
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("image exceeds the maximum size of %d bytes", maxSize)
+	}
+
+	contentType := http.DetectContentType(data)
+	extension, ok := AllowedImageTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported image type: %s", contentType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	if config.Width > MaxImageDimension || config.Height > MaxImageDimension {
+		return nil, fmt.Errorf("image dimensions %dx%d exceed the maximum of %dx%d pixels", config.Width, config.Height, MaxImageDimension, MaxImageDimension)
+	}
+	if config.Width*config.Height > MaxImagePixels {
+		return nil, fmt.Errorf("image has %d pixels, more than the maximum of %d", config.Width*config.Height, MaxImagePixels)
+	}
+
+	var img image.Image
+	switch contentType {
+	case "image/jpeg":
+		img, err = jpeg.Decode(bytes.NewReader(data))
+	case "image/png":
+		img, err = png.Decode(bytes.NewReader(data))
+	case "image/gif":
+		img, err = gif.Decode(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	processed := &ProcessedImage{
+		ContentType: contentType,
+		Extension:   extension,
+		Original:    data,
+		Thumbnails:  make(map[string][]byte, len(ThumbnailSizes)),
+	}
+
+	for name, size := range ThumbnailSizes {
+		thumbnail, err := encodeThumbnail(resizeToFit(img, size), contentType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s thumbnail: %w", name, err)
+		}
+		processed.Thumbnails[name] = thumbnail
+	}
+
+	return processed, nil
+}
+
+func ThumbnailContentType(contentType string) string {
+	if contentType == "image/jpeg" {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+func resizeToFit(img image.Image, maxDimension int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxDimension && height <= maxDimension {
+		return img
+	}
+
+	if width >= height {
+		height = height * maxDimension / width
+		width = maxDimension
+	} else {
+		width = width * maxDimension / height
+		height = maxDimension
+	}
+
+	resized := image.NewRGBA(image.Rect(0, 0, max(width, 1), max(height, 1)))
+	draw.CatmullRom.Scale(resized, resized.Bounds(), img, bounds, draw.Over, nil)
+	return resized
+}
+
+func encodeThumbnail(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if ThumbnailContentType(contentType) == "image/jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	return buf.Bytes(), err
+}
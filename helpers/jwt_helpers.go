@@ -2,55 +2,63 @@ package helpers
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/datarohit/go-restaurant-management-backend-project/config"
 	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
 	"github.com/dgrijalva/jwt-go"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
 )
 
 type SignedDetails struct {
-	Email     string
-	FirstName string
-	LastName  string
-	UID       string
+	Email      string
+	FirstName  string
+	LastName   string
+	UID        string
+	Role       string
+	IssuedAtMs int64 `json:"iatMs,omitempty"`
 	jwt.StandardClaims
 }
 
-var JWT_SECRET string = config.GetEnv("JWT_SECRET", "not-so-secret")
+type TokenManager struct {
+	secret          []byte
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+}
 
-func GenerateAllTokens(email, firstName, lastName, uid string) (string, string, error) {
-	if JWT_SECRET == "" {
-		return "", "", errors.New("JWT_SECRET is not set in the environment")
+func NewTokenManager(cfg config.AuthConfig) *TokenManager {
+	return &TokenManager{
+		secret:          []byte(cfg.JWTSecret),
+		accessTokenTTL:  cfg.AccessTokenTTL,
+		refreshTokenTTL: cfg.RefreshTokenTTL,
 	}
+}
 
-	claims := &SignedDetails{
-		Email:     email,
-		FirstName: firstName,
-		LastName:  lastName,
-		UID:       uid,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().UTC().Add(6 * time.Hour).Unix(),
-		},
+func (m *TokenManager) GenerateAllTokens(email, firstName, lastName, uid, role string) (string, string, error) {
+	if len(m.secret) == 0 {
+		return "", "", errors.New("JWT secret is not configured")
+	}
+
+	accessToken, err := m.GenerateToken(email, firstName, lastName, uid, role, m.accessTokenTTL)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate token: %w", err)
 	}
 
+	now := time.Now().UTC()
 	refreshClaims := &SignedDetails{
+		IssuedAtMs: now.UnixMilli(),
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().UTC().Add(24 * time.Hour).Unix(),
+			ExpiresAt: now.Add(m.refreshTokenTTL).Unix(),
+			IssuedAt:  now.Unix(),
 		},
 	}
 
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(JWT_SECRET))
-	if err != nil {
-		return "", "", fmt.Errorf("failed to generate token: %w", err)
-	}
-
-	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims).SignedString([]byte(JWT_SECRET))
+	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims).SignedString(m.secret)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
 	}
@@ -58,93 +66,81 @@ func GenerateAllTokens(email, firstName, lastName, uid string) (string, string,
 	return accessToken, refreshToken, nil
 }
 
-func UpdateAllTokens(signedAccessToken, signedRefreshToken, userId string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func UpdateAllTokens(ctx context.Context, users repositories.UserRepository, signedAccessToken, signedRefreshToken, userId string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	updateFields := bson.D{
-		{Key: "accessToken", Value: signedAccessToken},
-		{Key: "refreshToken", Value: signedRefreshToken},
-		{Key: "updatedAt", Value: time.Now().UTC()},
-	}
-
-	filter := bson.M{"userId": userId}
-	opts := options.Update().SetUpsert(true)
-
-	_, err := userCollection.UpdateOne(ctx, filter, bson.D{{Key: "$set", Value: updateFields}}, opts)
-	if err != nil {
-		log.Printf("Failed to update tokens for user %s: %v", userId, err)
+	if err := users.UpdateTokens(ctx, userId, HashToken(signedAccessToken), HashToken(signedRefreshToken)); err != nil {
+		utils.LoggerFrom(ctx).Error("Failed to update tokens", zap.String("userId", userId), zap.Error(err))
 		return err
 	}
 	return nil
 }
 
-func ValidateToken(tokenStr string) error {
-	_, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(JWT_SECRET), nil
-	})
-	return err
-}
-
-func GenerateToken(email, firstName, lastName, uid string, duration time.Duration) (string, error) {
-	claims := &SignedDetails{
-		Email:     email,
-		FirstName: firstName,
-		LastName:  lastName,
-		UID:       uid,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().UTC().Add(duration).Unix(),
-		},
-	}
-
-	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(JWT_SECRET))
+func (m *TokenManager) ParseToken(tokenStr string) (*SignedDetails, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &SignedDetails{}, m.keyFunc)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return token, nil
+	claims, ok := token.Claims.(*SignedDetails)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
 }
 
-func UpdateAccessToken(signedAccessToken, userId string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+func (m *TokenManager) ValidateToken(tokenStr string) error {
+	_, err := jwt.Parse(tokenStr, m.keyFunc)
+	return err
+}
 
-	updateFields := bson.D{
-		{Key: "accessToken", Value: signedAccessToken},
-		{Key: "updatedAt", Value: time.Now().UTC()},
+func RevokedBefore(user models.User, claims *SignedDetails) bool {
+	if user.TokensRevokedAt == nil {
+		return false
 	}
 
-	filter := bson.M{"userId": userId}
-	opts := options.Update().SetUpsert(true)
-
-	_, err := userCollection.UpdateOne(ctx, filter, bson.D{{Key: "$set", Value: updateFields}}, opts)
-	if err != nil {
-		log.Printf("Failed to update access token for user %s: %v", userId, err)
-		return err
+	issuedAt := claims.IssuedAtMs
+	if issuedAt == 0 {
+		issuedAt = claims.IssuedAt * 1000
 	}
-	return nil
+	return issuedAt < user.TokensRevokedAt.UnixMilli()
+}
+
+func IsCurrentToken(user models.User, token string) bool {
+	return user.AccessTokenHash != nil && subtle.ConstantTimeCompare([]byte(*user.AccessTokenHash), []byte(HashToken(token))) == 1
 }
 
-func GetOrGenerateTokens(user models.User) (string, string, error) {
-	if user.AccessToken != nil && ValidateToken(*user.AccessToken) == nil {
-		return *user.AccessToken, *user.RefreshToken, nil
+func (m *TokenManager) keyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 	}
+	return m.secret, nil
+}
 
-	if user.RefreshToken != nil && ValidateToken(*user.RefreshToken) == nil {
-		newAccessToken, err := GenerateToken(*user.Email, *user.FirstName, *user.LastName, user.UserID, 6*time.Hour)
-		if err != nil {
-			return "", "", err
-		}
-		return newAccessToken, *user.RefreshToken, nil
+func (m *TokenManager) GenerateToken(email, firstName, lastName, uid, role string, duration time.Duration) (string, error) {
+	now := time.Now().UTC()
+	claims := &SignedDetails{
+		Email:      email,
+		FirstName:  firstName,
+		LastName:   lastName,
+		UID:        uid,
+		Role:       role,
+		IssuedAtMs: now.UnixMilli(),
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: now.Add(duration).Unix(),
+			IssuedAt:  now.Unix(),
+		},
 	}
 
-	newAccessToken, newRefreshToken, err := GenerateAllTokens(*user.Email, *user.FirstName, *user.LastName, user.UserID)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
 	if err != nil {
-		return "", "", err
+		return "", err
 	}
 
-	return newAccessToken, newRefreshToken, nil
+	return token, nil
+}
+
+func (m *TokenManager) IssueTokens(user models.User) (string, string, error) {
+	return m.GenerateAllTokens(*user.Email, *user.FirstName, *user.LastName, user.UserID, GetNonNilString(user.Role, models.UserRoleStaff))
 }
@@ -0,0 +1,64 @@
+package helpers
+
+import (
+	"context"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/config"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+)
+
+type LoginLockout struct {
+	users       repositories.UserRepository
+	maxAttempts int
+	duration    time.Duration
+	maxDuration time.Duration
+}
+
+func NewLoginLockout(users repositories.UserRepository, cfg config.AuthConfig) *LoginLockout {
+	return &LoginLockout{
+		users:       users,
+		maxAttempts: cfg.MaxLoginAttempts,
+		duration:    cfg.LockoutDuration,
+		maxDuration: cfg.MaxLockout,
+	}
+}
+
+func (l *LoginLockout) LockedFor(user models.User, now time.Time) time.Duration {
+	if user.LockedUntil == nil || !user.LockedUntil.After(now) {
+		return 0
+	}
+	return user.LockedUntil.Sub(now)
+}
+
+func (l *LoginLockout) RecordFailure(ctx context.Context, user models.User) (time.Duration, error) {
+	attempts, err := l.users.RecordFailedLogin(ctx, user.UserID)
+	if err != nil || attempts < l.maxAttempts {
+		return 0, err
+	}
+
+	lockout := l.backoff(attempts - l.maxAttempts)
+	if err := l.users.LockUntil(ctx, user.UserID, time.Now().UTC().Add(lockout)); err != nil {
+		return 0, err
+	}
+	return lockout, nil
+}
+
+func (l *LoginLockout) RecordSuccess(ctx context.Context, user models.User) error {
+	if user.FailedLogins == 0 && user.LockedUntil == nil {
+		return nil
+	}
+	return l.users.ResetFailedLogins(ctx, user.UserID)
+}
+
+func (l *LoginLockout) backoff(step int) time.Duration {
+	lockout := l.duration
+	for i := 0; i < step && lockout < l.maxDuration; i++ {
+		lockout *= 2
+	}
+	if lockout > l.maxDuration {
+		return l.maxDuration
+	}
+	return lockout
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"time"
 
+	"github.com/datarohit/go-restaurant-management-backend-project/metrics"
 	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-func OrderItemOrderCreator(order models.Order, ctx context.Context) (string, error) {
+func OrderItemOrderCreator(ctx context.Context, orders repositories.OrderRepository, order models.Order) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -17,10 +19,10 @@ func OrderItemOrderCreator(order models.Order, ctx context.Context) (string, err
 	order.ID = primitive.NewObjectID()
 	order.OrderID = order.ID.Hex()
 
-	_, err := orderCollection.InsertOne(ctx, order)
-	if err != nil {
+	if err := orders.Create(ctx, &order); err != nil {
 		return "", err
 	}
+	metrics.OrderPlaced()
 
 	return order.OrderID, nil
 }
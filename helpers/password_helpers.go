@@ -1,19 +1,19 @@
 package helpers
 
 import (
-	"log"
+	"fmt"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
-func HashPassword(password *string) *string {
+func HashPassword(password *string) (*string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(*password), bcrypt.DefaultCost)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
 	hashedPassword := string(bytes)
-	return &hashedPassword
+	return &hashedPassword, nil
 }
 
 func VerifyPassword(hashedPassword, plainPassword string) (bool, string) {
@@ -0,0 +1,127 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/metrics"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+func RecordFoodPrice(ctx context.Context, prices repositories.FoodPriceRepository, foodID string, price float64, effectiveFrom time.Time) (models.FoodPrice, error) {
+	roundedPrice := ToFixed(price, 2)
+	effectiveFrom = effectiveFrom.UTC()
+
+	foodPrice := models.FoodPrice{
+		ID:            primitive.NewObjectID(),
+		Price:         &roundedPrice,
+		EffectiveFrom: &effectiveFrom,
+		Applied:       !effectiveFrom.After(time.Now().UTC()),
+		CreatedAt:     time.Now().UTC(),
+		UpdatedAt:     time.Now().UTC(),
+		FoodID:        foodID,
+	}
+	foodPrice.FoodPriceID = foodPrice.ID.Hex()
+
+	err := prices.Create(ctx, &foodPrice)
+	return foodPrice, err
+}
+
+func GetEffectivePrice(ctx context.Context, prices repositories.FoodPriceRepository, food models.Food, at time.Time) (*float64, error) {
+	foodPrice, err := prices.FindEffective(ctx, food.FoodID, at.UTC())
+	if errors.Is(err, repositories.ErrNotFound) {
+		return food.Price, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return foodPrice.Price, nil
+}
+
+func ApplyScheduledPrices(ctx context.Context, foods repositories.FoodRepository, prices repositories.FoodPriceRepository, now time.Time) (int, error) {
+	due, err := prices.ListDue(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	byFood := make(map[string][]models.FoodPrice)
+	foodIDs := []string{}
+	for _, price := range due {
+		if _, seen := byFood[price.FoodID]; !seen {
+			foodIDs = append(foodIDs, price.FoodID)
+		}
+		byFood[price.FoodID] = append(byFood[price.FoodID], price)
+	}
+
+	ctx = repositories.WithDeleted(ctx)
+	applied := 0
+	var errs []error
+	for _, foodID := range foodIDs {
+		count, err := applyFoodPrices(ctx, foods, prices, foodID, byFood[foodID], now)
+		applied += count
+		if err != nil {
+			utils.GetLogger().Error("Failed to apply scheduled prices for food", zap.String("foodId", foodID), zap.Error(err))
+			errs = append(errs, fmt.Errorf("food %s: %w", foodID, err))
+		}
+	}
+	return applied, errors.Join(errs...)
+}
+
+func applyFoodPrices(ctx context.Context, foods repositories.FoodRepository, prices repositories.FoodPriceRepository, foodID string, due []models.FoodPrice, now time.Time) (int, error) {
+	food, err := foods.FindByID(ctx, foodID)
+	if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+		return 0, err
+	}
+
+	if food != nil {
+		effectivePrice, err := GetEffectivePrice(ctx, prices, *food, now)
+		if err != nil {
+			return 0, err
+		}
+
+		if effectivePrice != nil && (food.Price == nil || *food.Price != *effectivePrice) {
+			food.Price = effectivePrice
+			food.UpdatedAt = now.UTC()
+			if err := foods.Update(ctx, food); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	applied := 0
+	for _, price := range due {
+		if err := prices.MarkApplied(ctx, price.FoodPriceID); err != nil {
+			return applied, err
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+func RunPriceScheduler(ctx context.Context, foods repositories.FoodRepository, prices repositories.FoodPriceRepository, interval time.Duration, tick func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			applied, err := ApplyScheduledPrices(ctx, foods, prices, now.UTC())
+			if err != nil {
+				utils.GetLogger().Error("Failed to apply scheduled food prices", zap.Error(err))
+			}
+			if applied > 0 {
+				utils.GetLogger().Info("Applied scheduled food prices", zap.Int("count", applied))
+			}
+			metrics.JobRun("price-scheduler", err)
+			tick()
+		}
+	}
+}
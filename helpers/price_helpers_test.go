@@ -0,0 +1,75 @@
+package helpers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type failingFoodRepository struct {
+	repositories.FoodRepository
+	failFoodID string
+}
+
+func (r failingFoodRepository) Update(ctx context.Context, food *models.Food) error {
+	if food.FoodID == r.failFoodID {
+		return errors.New("write failed")
+	}
+	return r.FoodRepository.Update(ctx, food)
+}
+
+func TestApplyScheduledPricesContinuesPastAFailingFood(t *testing.T) {
+	ctx := context.Background()
+	repos := repositories.NewMemoryRepositories()
+	now := time.Now().UTC()
+
+	var foodIDs []string
+	for _, name := range []string{"Pizza", "Pasta", "Salad"} {
+		food := models.Food{ID: primitive.NewObjectID(), Name: ptr(name), Price: ptr(10.0), CreatedAt: now, UpdatedAt: now}
+		food.FoodID = food.ID.Hex()
+		if err := repos.Foods.Create(ctx, &food); err != nil {
+			t.Fatalf("creating food: %v", err)
+		}
+		if _, err := helper.RecordFoodPrice(ctx, repos.FoodPrices, food.FoodID, 12, now.Add(time.Hour)); err != nil {
+			t.Fatalf("scheduling price: %v", err)
+		}
+		foodIDs = append(foodIDs, food.FoodID)
+	}
+
+	foods := failingFoodRepository{FoodRepository: repos.Foods, failFoodID: foodIDs[0]}
+	applied, err := helper.ApplyScheduledPrices(ctx, foods, repos.FoodPrices, now.Add(2*time.Hour))
+	if err == nil {
+		t.Fatal("expected the failing food to be reported")
+	}
+	if applied != 2 {
+		t.Fatalf("expected the other two prices to be applied, got %d", applied)
+	}
+
+	for i, foodID := range foodIDs {
+		food, err := repos.Foods.FindByID(ctx, foodID)
+		if err != nil {
+			t.Fatalf("loading food: %v", err)
+		}
+		want := 12.0
+		if i == 0 {
+			want = 10
+		}
+		if *food.Price != want {
+			t.Fatalf("expected food %d to cost %v, got %v", i, want, *food.Price)
+		}
+	}
+
+	due, err := repos.FoodPrices.ListDue(ctx, now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("listing due prices: %v", err)
+	}
+	if len(due) != 1 || due[0].FoodID != foodIDs[0] {
+		t.Fatalf("expected only the failing food's price to stay due, got %+v", due)
+	}
+}
@@ -0,0 +1,336 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+)
+
+type PromotionLine struct {
+	OrderItemID string
+	FoodID      string
+	MenuID      string
+	Category    string
+	UnitPrice   float64
+}
+
+type PromotionSummary struct {
+	Subtotal          float64                   `json:"subtotal"`
+	DiscountTotal     float64                   `json:"discountTotal"`
+	Total             float64                   `json:"total"`
+	AppliedPromotions []models.AppliedPromotion `json:"appliedPromotions"`
+}
+
+type PromotionEvaluator struct {
+	orderItems repositories.OrderItemRepository
+	foods      repositories.FoodRepository
+	menus      repositories.MenuRepository
+	promotions repositories.PromotionRepository
+	location   *time.Location
+}
+
+func NewPromotionEvaluator(orderItems repositories.OrderItemRepository, foods repositories.FoodRepository, menus repositories.MenuRepository, promotions repositories.PromotionRepository, location *time.Location) *PromotionEvaluator {
+	return &PromotionEvaluator{
+		orderItems: orderItems,
+		foods:      foods,
+		menus:      menus,
+		promotions: promotions,
+		location:   location,
+	}
+}
+
+func (e *PromotionEvaluator) EvaluateOrder(ctx context.Context, order models.Order) (PromotionSummary, error) {
+	orderItems, err := e.orderItems.ListByOrder(ctx, order.OrderID)
+	if err != nil {
+		return PromotionSummary{}, err
+	}
+
+	lines, err := e.buildLines(ctx, orderItems)
+	if err != nil {
+		return PromotionSummary{}, err
+	}
+
+	promotions, err := e.promotions.ListActive(ctx)
+	if err != nil {
+		return PromotionSummary{}, err
+	}
+
+	return ApplyPromotions(promotions, lines, order.OrderDate, e.location), nil
+}
+
+func (e *PromotionEvaluator) TotalInvoice(ctx context.Context, invoice *models.Invoice, order models.Order) (bool, error) {
+	summary, err := e.EvaluateOrder(ctx, order)
+	if err != nil {
+		return false, err
+	}
+
+	changed := invoice.Subtotal != summary.Subtotal ||
+		invoice.DiscountTotal != summary.DiscountTotal ||
+		invoice.Total != summary.Total ||
+		len(invoice.AppliedPromotions) != len(summary.AppliedPromotions)
+
+	invoice.Subtotal = summary.Subtotal
+	invoice.DiscountTotal = summary.DiscountTotal
+	invoice.Total = summary.Total
+	invoice.AppliedPromotions = summary.AppliedPromotions
+	return changed, nil
+}
+
+func RefreshInvoiceTotals(ctx context.Context, evaluator *PromotionEvaluator, invoices repositories.InvoiceRepository, orders repositories.OrderRepository, orderID string) error {
+	order, err := orders.FindByID(ctx, orderID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	orderInvoices, err := invoices.ListByOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	for i := range orderInvoices {
+		invoice := &orderInvoices[i]
+		if invoice.PaymentStatus != nil && *invoice.PaymentStatus == "PAID" {
+			continue
+		}
+
+		changed, err := evaluator.TotalInvoice(ctx, invoice, *order)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+
+		invoice.UpdatedAt = time.Now().UTC()
+		if err := invoices.Update(ctx, invoice); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *PromotionEvaluator) buildLines(ctx context.Context, orderItems []models.OrderItem) ([]PromotionLine, error) {
+	var foodIDs []string
+	for _, item := range orderItems {
+		if item.FoodID != nil {
+			foodIDs = append(foodIDs, *item.FoodID)
+		}
+	}
+
+	foods, err := e.foods.FindByIDs(repositories.WithDeleted(ctx), foodIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	foodMenus := make(map[string]string, len(foods))
+	var menuIDs []string
+	for _, food := range foods {
+		if food.MenuID != nil {
+			foodMenus[food.FoodID] = *food.MenuID
+			menuIDs = append(menuIDs, *food.MenuID)
+		}
+	}
+
+	menus, err := e.menus.FindByIDs(repositories.WithDeleted(ctx), menuIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	menuCategories := make(map[string]string, len(menus))
+	for _, menu := range menus {
+		menuCategories[menu.MenuID] = menu.Category
+	}
+
+	lines := make([]PromotionLine, 0, len(orderItems))
+	for _, item := range orderItems {
+		line := PromotionLine{OrderItemID: item.OrderItemID}
+		if item.FoodID != nil {
+			line.FoodID = *item.FoodID
+			line.MenuID = foodMenus[line.FoodID]
+			line.Category = menuCategories[line.MenuID]
+		}
+		if item.UnitPrice != nil {
+			line.UnitPrice = *item.UnitPrice
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+func ApplyPromotions(promotions []models.Promotion, lines []PromotionLine, at time.Time, location *time.Location) PromotionSummary {
+	summary := PromotionSummary{AppliedPromotions: []models.AppliedPromotion{}}
+	for _, line := range lines {
+		summary.Subtotal += line.UnitPrice
+	}
+	summary.Subtotal = ToFixed(summary.Subtotal, 2)
+
+	if location == nil {
+		location = time.UTC
+	}
+	localAt := at.In(location)
+
+	for _, promotion := range promotions {
+		if !PromotionIsActiveAt(promotion, localAt) {
+			continue
+		}
+
+		if promotion.MinimumSpend != nil && summary.Subtotal < *promotion.MinimumSpend {
+			continue
+		}
+
+		var matched []PromotionLine
+		for _, line := range lines {
+			if promotionMatchesLine(promotion, line) {
+				matched = append(matched, line)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		discount := ToFixed(promotionDiscount(promotion, matched), 2)
+		remaining := ToFixed(summary.Subtotal-summary.DiscountTotal, 2)
+		discount = math.Min(discount, remaining)
+		if discount <= 0 {
+			continue
+		}
+
+		orderItemIDs := make([]string, 0, len(matched))
+		for _, line := range matched {
+			orderItemIDs = append(orderItemIDs, line.OrderItemID)
+		}
+
+		summary.DiscountTotal = ToFixed(summary.DiscountTotal+discount, 2)
+		summary.AppliedPromotions = append(summary.AppliedPromotions, models.AppliedPromotion{
+			PromotionID:  promotion.PromotionID,
+			Name:         GetNonNilString(promotion.Name, ""),
+			Type:         GetNonNilString(promotion.Type, ""),
+			Discount:     discount,
+			OrderItemIDs: orderItemIDs,
+		})
+	}
+
+	summary.Total = ToFixed(summary.Subtotal-summary.DiscountTotal, 2)
+	return summary
+}
+
+func PromotionIsActiveAt(promotion models.Promotion, at time.Time) bool {
+	if promotion.Active != nil && !*promotion.Active {
+		return false
+	}
+
+	if promotion.StartDate != nil && at.Before(*promotion.StartDate) {
+		return false
+	}
+
+	if promotion.EndDate != nil && at.After(*promotion.EndDate) {
+		return false
+	}
+
+	if len(promotion.DaysOfWeek) > 0 {
+		dayMatches := false
+		for _, day := range promotion.DaysOfWeek {
+			if time.Weekday(day) == at.Weekday() {
+				dayMatches = true
+				break
+			}
+		}
+		if !dayMatches {
+			return false
+		}
+	}
+
+	if promotion.StartTime != nil && promotion.EndTime != nil {
+		start, startErr := time.Parse("15:04", *promotion.StartTime)
+		end, endErr := time.Parse("15:04", *promotion.EndTime)
+		if startErr != nil || endErr != nil {
+			return false
+		}
+
+		minuteOfDay := at.Hour()*60 + at.Minute()
+		startMinute := start.Hour()*60 + start.Minute()
+		endMinute := end.Hour()*60 + end.Minute()
+
+		if startMinute <= endMinute {
+			return minuteOfDay >= startMinute && minuteOfDay < endMinute
+		}
+		return minuteOfDay >= startMinute || minuteOfDay < endMinute
+	}
+
+	return true
+}
+
+func promotionMatchesLine(promotion models.Promotion, line PromotionLine) bool {
+	if len(promotion.FoodIDs) == 0 && len(promotion.MenuIDs) == 0 && len(promotion.Categories) == 0 {
+		return true
+	}
+
+	for _, foodID := range promotion.FoodIDs {
+		if foodID == line.FoodID {
+			return true
+		}
+	}
+
+	for _, menuID := range promotion.MenuIDs {
+		if menuID == line.MenuID {
+			return true
+		}
+	}
+
+	for _, category := range promotion.Categories {
+		if strings.EqualFold(category, line.Category) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func promotionDiscount(promotion models.Promotion, lines []PromotionLine) float64 {
+	var matchedSubtotal float64
+	for _, line := range lines {
+		matchedSubtotal += line.UnitPrice
+	}
+
+	value := 0.0
+	if promotion.DiscountValue != nil {
+		value = *promotion.DiscountValue
+	}
+
+	switch GetNonNilString(promotion.Type, "") {
+	case models.PromotionTypePercentage:
+		return matchedSubtotal * math.Min(value, 100) / 100
+	case models.PromotionTypeFixed:
+		return math.Min(value, matchedSubtotal)
+	case models.PromotionTypeBuyXGetY:
+		if promotion.BuyQuantity == nil || promotion.GetQuantity == nil {
+			return 0
+		}
+
+		prices := make([]float64, 0, len(lines))
+		for _, line := range lines {
+			prices = append(prices, line.UnitPrice)
+		}
+		sort.Sort(sort.Reverse(sort.Float64Slice(prices)))
+
+		groupSize := *promotion.BuyQuantity + *promotion.GetQuantity
+		var discount float64
+		for start := 0; start+groupSize <= len(prices); start += groupSize {
+			for _, price := range prices[start+*promotion.BuyQuantity : start+groupSize] {
+				discount += price
+			}
+		}
+		return discount
+	}
+
+	return 0
+}
@@ -0,0 +1,197 @@
+package helpers_test
+
+import (
+	"testing"
+	"time"
+
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+)
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func promotion(id, promotionType string, discountValue float64) models.Promotion {
+	return models.Promotion{
+		PromotionID:   id,
+		Name:          ptr(id),
+		Type:          ptr(promotionType),
+		DiscountValue: ptr(discountValue),
+	}
+}
+
+func buyXGetY(id string, buy, get int) models.Promotion {
+	return models.Promotion{
+		PromotionID: id,
+		Name:        ptr(id),
+		Type:        ptr(models.PromotionTypeBuyXGetY),
+		BuyQuantity: ptr(buy),
+		GetQuantity: ptr(get),
+	}
+}
+
+func lines(prices ...float64) []helper.PromotionLine {
+	result := make([]helper.PromotionLine, 0, len(prices))
+	for i, price := range prices {
+		result = append(result, helper.PromotionLine{
+			OrderItemID: string(rune('a' + i)),
+			FoodID:      "food",
+			MenuID:      "menu",
+			Category:    "Mains",
+			UnitPrice:   price,
+		})
+	}
+	return result
+}
+
+func TestApplyPromotions(t *testing.T) {
+	at := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+
+	scopedToFood := promotion("scoped", models.PromotionTypePercentage, 50)
+	scopedToFood.FoodIDs = []string{"pizza"}
+
+	scopedToCategory := promotion("category", models.PromotionTypeFixed, 5)
+	scopedToCategory.Categories = []string{"drinks"}
+
+	minimumSpend := promotion("minimum", models.PromotionTypeFixed, 5)
+	minimumSpend.MinimumSpend = ptr(50.0)
+
+	inactive := promotion("inactive", models.PromotionTypeFixed, 5)
+	inactive.Active = ptr(false)
+
+	mixed := []helper.PromotionLine{
+		{OrderItemID: "a", FoodID: "pizza", Category: "Mains", UnitPrice: 12},
+		{OrderItemID: "b", FoodID: "soda", Category: "Drinks", UnitPrice: 3},
+		{OrderItemID: "c", FoodID: "pasta", Category: "Mains", UnitPrice: 10},
+	}
+
+	tests := []struct {
+		name       string
+		promotions []models.Promotion
+		lines      []helper.PromotionLine
+		discount   float64
+		applied    []string
+	}{
+		{"percentage", []models.Promotion{promotion("ten", models.PromotionTypePercentage, 10)}, lines(10, 20.5), 3.05, []string{"ten"}},
+		{"percentage is capped at 100", []models.Promotion{promotion("all", models.PromotionTypePercentage, 150)}, lines(10, 20), 30, []string{"all"}},
+		{"fixed", []models.Promotion{promotion("five", models.PromotionTypeFixed, 5)}, lines(10, 20), 5, []string{"five"}},
+		{"fixed is capped at the matched subtotal", []models.Promotion{promotion("big", models.PromotionTypeFixed, 50)}, lines(10, 20), 30, []string{"big"}},
+		{"scoped to a food", []models.Promotion{scopedToFood}, mixed, 6, []string{"scoped"}},
+		{"scoped to a category ignores case", []models.Promotion{scopedToCategory}, mixed, 3, []string{"category"}},
+		{"no matching lines", []models.Promotion{scopedToFood}, lines(10), 0, nil},
+		{"minimum spend not reached", []models.Promotion{minimumSpend}, lines(20, 29.99), 0, nil},
+		{"minimum spend reached", []models.Promotion{minimumSpend}, lines(20, 30), 5, []string{"minimum"}},
+		{"inactive promotion", []models.Promotion{inactive}, lines(10), 0, nil},
+		{"buy two get one frees the cheapest in each group", []models.Promotion{buyXGetY("b2g1", 2, 1)}, lines(4, 10, 6, 8, 2, 3), 8, []string{"b2g1"}},
+		{"buy two get one needs a full group", []models.Promotion{buyXGetY("b2g1", 2, 1)}, lines(4, 10), 0, nil},
+		{
+			"stacked promotions never exceed the subtotal",
+			[]models.Promotion{
+				promotion("half", models.PromotionTypePercentage, 50),
+				promotion("forty", models.PromotionTypeFixed, 40),
+				promotion("five", models.PromotionTypeFixed, 5),
+			},
+			lines(20, 40),
+			60,
+			[]string{"half", "forty"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := helper.ApplyPromotions(tt.promotions, tt.lines, at, time.UTC)
+
+			var subtotal float64
+			for _, line := range tt.lines {
+				subtotal += line.UnitPrice
+			}
+			subtotal = helper.ToFixed(subtotal, 2)
+
+			if summary.Subtotal != subtotal {
+				t.Fatalf("expected subtotal %v, got %v", subtotal, summary.Subtotal)
+			}
+			if summary.DiscountTotal != tt.discount {
+				t.Fatalf("expected discount %v, got %v", tt.discount, summary.DiscountTotal)
+			}
+			if want := helper.ToFixed(subtotal-tt.discount, 2); summary.Total != want {
+				t.Fatalf("expected total %v, got %v", want, summary.Total)
+			}
+
+			if len(summary.AppliedPromotions) != len(tt.applied) {
+				t.Fatalf("expected %d applied promotions, got %+v", len(tt.applied), summary.AppliedPromotions)
+			}
+			for i, id := range tt.applied {
+				if summary.AppliedPromotions[i].PromotionID != id {
+					t.Fatalf("expected promotion %s at position %d, got %s", id, i, summary.AppliedPromotions[i].PromotionID)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyPromotionsUsesTheRestaurantTimeZone(t *testing.T) {
+	happyHour := promotion("happy", models.PromotionTypePercentage, 10)
+	happyHour.StartTime = ptr("17:00")
+	happyHour.EndTime = ptr("19:00")
+
+	location := time.FixedZone("UTC+5", 5*60*60)
+	at := time.Date(2026, time.March, 4, 12, 30, 0, 0, time.UTC)
+
+	if summary := helper.ApplyPromotions([]models.Promotion{happyHour}, lines(10), at, location); summary.DiscountTotal != 1 {
+		t.Fatalf("expected the happy hour to apply at 17:30 local time, got %+v", summary)
+	}
+	if summary := helper.ApplyPromotions([]models.Promotion{happyHour}, lines(10), at, time.UTC); summary.DiscountTotal != 0 {
+		t.Fatalf("expected no discount at 12:30 UTC, got %+v", summary)
+	}
+}
+
+func TestPromotionIsActiveAt(t *testing.T) {
+	// 2026-03-04 is a Wednesday.
+	day := func(hour, minute int) time.Time {
+		return time.Date(2026, time.March, 4, hour, minute, 0, 0, time.UTC)
+	}
+
+	lateNight := models.Promotion{StartTime: ptr("22:00"), EndTime: ptr("02:00")}
+	lunch := models.Promotion{StartTime: ptr("12:00"), EndTime: ptr("14:00")}
+	weekdays := models.Promotion{DaysOfWeek: []int{1, 2, 3, 4, 5}}
+	weekends := models.Promotion{DaysOfWeek: []int{0, 6}}
+	dated := models.Promotion{StartDate: ptr(day(12, 0)), EndDate: ptr(day(18, 0))}
+	onlyStart := models.Promotion{StartTime: ptr("22:00")}
+	malformed := models.Promotion{StartTime: ptr("22:00"), EndTime: ptr("late")}
+
+	tests := []struct {
+		name      string
+		promotion models.Promotion
+		at        time.Time
+		active    bool
+	}{
+		{"no rules", models.Promotion{}, day(3, 0), true},
+		{"deactivated", models.Promotion{Active: ptr(false)}, day(3, 0), false},
+		{"window start is inclusive", lunch, day(12, 0), true},
+		{"window end is exclusive", lunch, day(14, 0), false},
+		{"before window", lunch, day(11, 59), false},
+		{"overnight window before midnight", lateNight, day(23, 30), true},
+		{"overnight window at midnight", lateNight, day(0, 0), true},
+		{"overnight window after midnight", lateNight, day(1, 59), true},
+		{"overnight window end is exclusive", lateNight, day(2, 0), false},
+		{"overnight window start is inclusive", lateNight, day(22, 0), true},
+		{"overnight window outside", lateNight, day(21, 59), false},
+		{"matching weekday", weekdays, day(12, 0), true},
+		{"other weekday", weekends, day(12, 0), false},
+		{"start date is inclusive", dated, day(12, 0), true},
+		{"before start date", dated, day(11, 59), false},
+		{"end date is inclusive", dated, day(18, 0), true},
+		{"after end date", dated, day(18, 1), false},
+		{"start time without end time", onlyStart, day(3, 0), true},
+		{"malformed time", malformed, day(23, 0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if active := helper.PromotionIsActiveAt(tt.promotion, tt.at); active != tt.active {
+				t.Fatalf("expected active=%v at %s, got %v", tt.active, tt.at.Format(time.RFC3339), active)
+			}
+		})
+	}
+}
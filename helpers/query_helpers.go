@@ -0,0 +1,330 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FieldType int
+
+const (
+	StringField FieldType = iota
+	NumberField
+	TimeField
+	BoolField
+)
+
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 100
+)
+
+type ListSpec struct {
+	Filters     map[string]FieldType
+	Sorts       []string
+	DefaultSort string
+	Params      []string
+}
+
+type ListLinks struct {
+	Self string  `json:"self"`
+	Next *string `json:"next"`
+	Prev *string `json:"prev"`
+}
+
+type CursorListResponse struct {
+	Items      interface{} `json:"items"`
+	Limit      int64       `json:"limit"`
+	NextCursor *string     `json:"nextCursor"`
+	PrevCursor *string     `json:"prevCursor"`
+	Links      ListLinks   `json:"links"`
+}
+
+type cursorToken struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+	Backward  bool      `json:"b,omitempty"`
+}
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+var ErrCursorWithPage = errors.New("cursor cannot be combined with page or sort")
+
+type ListResponse struct {
+	Items interface{} `json:"items"`
+	Total int64       `json:"total"`
+	Page  int64       `json:"page"`
+	Limit int64       `json:"limit"`
+	Links ListLinks   `json:"links"`
+}
+
+var filterKeyPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9.]*)(?:\[([a-z]+)\])?$`)
+
+var listParams = []string{"page", "limit", "recordPerPage", "sort", "cursor", "paginate", "includeDeleted"}
+
+var filterOperators = map[string]repositories.Operator{
+	"eq":  repositories.OpEq,
+	"ne":  repositories.OpNe,
+	"gt":  repositories.OpGt,
+	"gte": repositories.OpGte,
+	"lt":  repositories.OpLt,
+	"lte": repositories.OpLte,
+	"in":  repositories.OpIn,
+}
+
+func ParseListQuery(c *gin.Context, spec ListSpec) (repositories.Query, error) {
+	var query repositories.Query
+
+	limit, page, err := parsePage(c)
+	if err != nil {
+		return query, err
+	}
+	query.Limit = limit
+	query.Skip = (page - 1) * limit
+
+	query.Sort, err = parseSort(c.DefaultQuery("sort", spec.DefaultSort), spec.Sorts)
+	if err != nil {
+		return query, err
+	}
+
+	values := c.Request.URL.Query()
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if containsParam(listParams, key) || containsParam(spec.Params, key) {
+			continue
+		}
+
+		match := filterKeyPattern.FindStringSubmatch(key)
+		if match == nil {
+			return query, fmt.Errorf("invalid query parameter: %s", key)
+		}
+
+		field, rawOperator := match[1], match[2]
+		fieldType, ok := spec.Filters[field]
+		if !ok {
+			return query, fmt.Errorf("filtering by %s is not supported", field)
+		}
+
+		operator := repositories.OpEq
+		if rawOperator != "" {
+			if operator, ok = filterOperators[rawOperator]; !ok {
+				return query, fmt.Errorf("unknown operator %s for %s", rawOperator, field)
+			}
+		}
+
+		for _, raw := range values[key] {
+			value, err := parseFilterValue(raw, operator, fieldType)
+			if err != nil {
+				return query, fmt.Errorf("invalid value for %s: %w", key, err)
+			}
+			query = query.Where(field, operator, value)
+		}
+	}
+
+	return query, nil
+}
+
+func parsePage(c *gin.Context) (int64, int64, error) {
+	limit := int64(defaultPageLimit)
+	rawLimit := c.Query("limit")
+	if rawLimit == "" {
+		rawLimit = c.Query("recordPerPage")
+	}
+	if rawLimit != "" {
+		parsed, err := strconv.ParseInt(rawLimit, 10, 64)
+		if err != nil || parsed < 1 {
+			return 0, 0, fmt.Errorf("limit must be a positive integer")
+		}
+		limit = min(parsed, maxPageLimit)
+	}
+
+	page := int64(1)
+	if rawPage := c.Query("page"); rawPage != "" {
+		parsed, err := strconv.ParseInt(rawPage, 10, 64)
+		if err != nil || parsed < 1 {
+			return 0, 0, fmt.Errorf("page must be a positive integer")
+		}
+		page = parsed
+	}
+
+	return limit, page, nil
+}
+
+func parseSort(raw string, allowed []string) ([]repositories.SortField, error) {
+	var fields []repositories.SortField
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		descending := strings.HasPrefix(part, "-")
+		field := strings.TrimLeft(part, "+-")
+		if !containsParam(allowed, field) {
+			return nil, fmt.Errorf("sorting by %s is not supported", field)
+		}
+		fields = append(fields, repositories.SortField{Field: field, Descending: descending})
+	}
+	return fields, nil
+}
+
+func parseFilterValue(raw string, operator repositories.Operator, fieldType FieldType) (interface{}, error) {
+	if operator == repositories.OpIn {
+		var values []interface{}
+		for _, part := range strings.Split(raw, ",") {
+			value, err := parseScalar(strings.TrimSpace(part), fieldType)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+	return parseScalar(raw, fieldType)
+}
+
+func parseScalar(raw string, fieldType FieldType) (interface{}, error) {
+	switch fieldType {
+	case NumberField:
+		return strconv.ParseFloat(raw, 64)
+	case BoolField:
+		return strconv.ParseBool(raw)
+	case TimeField:
+		if parsed, err := time.Parse(time.RFC3339, raw); err == nil {
+			return parsed.UTC(), nil
+		}
+		parsed, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			return nil, fmt.Errorf("expected an RFC3339 timestamp or a YYYY-MM-DD date")
+		}
+		return parsed.UTC(), nil
+	}
+	return raw, nil
+}
+
+func containsParam(params []string, param string) bool {
+	for _, p := range params {
+		if p == param {
+			return true
+		}
+	}
+	return false
+}
+
+func NewListResponse(c *gin.Context, items interface{}, total int64, query repositories.Query) ListResponse {
+	page := query.Skip/query.Limit + 1
+
+	response := ListResponse{
+		Items: items,
+		Total: total,
+		Page:  page,
+		Limit: query.Limit,
+		Links: ListLinks{Self: pageLink(c.Request.URL, page, query.Limit)},
+	}
+
+	if query.Skip+query.Limit < total {
+		next := pageLink(c.Request.URL, page+1, query.Limit)
+		response.Links.Next = &next
+	}
+	if page > 1 {
+		prev := pageLink(c.Request.URL, page-1, query.Limit)
+		response.Links.Prev = &prev
+	}
+	return response
+}
+
+func UsesCursor(c *gin.Context) bool {
+	return c.Query("cursor") != "" || c.Query("paginate") == "cursor"
+}
+
+func ParseCursor(c *gin.Context) (*repositories.Cursor, error) {
+	if c.Query("page") != "" || c.Query("sort") != "" {
+		return nil, ErrCursorWithPage
+	}
+
+	raw := c.Query("cursor")
+	if raw == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var token cursorToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	id, err := primitive.ObjectIDFromHex(token.ID)
+	if err != nil || token.CreatedAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+
+	return &repositories.Cursor{CreatedAt: token.CreatedAt.UTC(), ID: id, Backward: token.Backward}, nil
+}
+
+func EncodeCursor(cursor repositories.Cursor) string {
+	data, _ := json.Marshal(cursorToken{CreatedAt: cursor.CreatedAt, ID: cursor.ID.Hex(), Backward: cursor.Backward})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func NewCursorListResponse(c *gin.Context, items interface{}, page repositories.CursorPage, query repositories.Query) CursorListResponse {
+	response := CursorListResponse{
+		Items: items,
+		Limit: query.Limit,
+		Links: ListLinks{Self: c.Request.URL.RequestURI()},
+	}
+
+	if page.Next != nil {
+		next := EncodeCursor(*page.Next)
+		link := cursorLink(c.Request.URL, next, query.Limit)
+		response.NextCursor = &next
+		response.Links.Next = &link
+	}
+	if page.Prev != nil {
+		prev := EncodeCursor(*page.Prev)
+		link := cursorLink(c.Request.URL, prev, query.Limit)
+		response.PrevCursor = &prev
+		response.Links.Prev = &link
+	}
+	return response
+}
+
+func cursorLink(current *url.URL, cursor string, limit int64) string {
+	values := current.Query()
+	values.Del("recordPerPage")
+	values.Set("cursor", cursor)
+	values.Set("limit", strconv.FormatInt(limit, 10))
+
+	link := url.URL{Path: current.Path, RawQuery: values.Encode()}
+	return link.String()
+}
+
+func pageLink(current *url.URL, page, limit int64) string {
+	values := current.Query()
+	values.Del("recordPerPage")
+	values.Set("page", strconv.FormatInt(page, 10))
+	values.Set("limit", strconv.FormatInt(limit, 10))
+
+	link := url.URL{Path: current.Path, RawQuery: values.Encode()}
+	return link.String()
+}
@@ -0,0 +1,182 @@
+package helpers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func contextFor(target string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	return c
+}
+
+var tableSpec = helper.ListSpec{
+	Filters: map[string]helper.FieldType{
+		"tableNumber":    helper.NumberField,
+		"status":         helper.StringField,
+		"createdAt":      helper.TimeField,
+		"reserved":       helper.BoolField,
+		"numberOfGuests": helper.NumberField,
+	},
+	Sorts:       []string{"tableNumber", "createdAt"},
+	DefaultSort: "-createdAt",
+	Params:      []string{"search"},
+}
+
+func TestParseListQuery(t *testing.T) {
+	day := time.Date(2026, time.March, 4, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		target string
+		want   repositories.Query
+	}{
+		{
+			"defaults",
+			"/tables",
+			repositories.Query{Limit: 10, Sort: []repositories.SortField{{Field: "createdAt", Descending: true}}},
+		},
+		{
+			"page and limit",
+			"/tables?page=3&limit=20",
+			repositories.Query{Skip: 40, Limit: 20, Sort: []repositories.SortField{{Field: "createdAt", Descending: true}}},
+		},
+		{
+			"legacy recordPerPage",
+			"/tables?recordPerPage=5",
+			repositories.Query{Limit: 5, Sort: []repositories.SortField{{Field: "createdAt", Descending: true}}},
+		},
+		{
+			"limit is capped",
+			"/tables?limit=1000",
+			repositories.Query{Limit: 100, Sort: []repositories.SortField{{Field: "createdAt", Descending: true}}},
+		},
+		{
+			"multiple sort fields",
+			"/tables?sort=tableNumber,-createdAt",
+			repositories.Query{Limit: 10, Sort: []repositories.SortField{{Field: "tableNumber"}, {Field: "createdAt", Descending: true}}},
+		},
+		{
+			"typed filters in key order",
+			"/tables?tableNumber[gte]=2&status=free&reserved=true&createdAt[lt]=2026-03-04&search=ignored",
+			repositories.Query{
+				Limit: 10,
+				Sort:  []repositories.SortField{{Field: "createdAt", Descending: true}},
+				Conditions: []repositories.Condition{
+					{Field: "createdAt", Operator: repositories.OpLt, Value: day},
+					{Field: "reserved", Operator: repositories.OpEq, Value: true},
+					{Field: "status", Operator: repositories.OpEq, Value: "free"},
+					{Field: "tableNumber", Operator: repositories.OpGte, Value: 2.0},
+				},
+			},
+		},
+		{
+			"in operator",
+			"/tables?numberOfGuests[in]=2,%204",
+			repositories.Query{
+				Limit:      10,
+				Sort:       []repositories.SortField{{Field: "createdAt", Descending: true}},
+				Conditions: []repositories.Condition{{Field: "numberOfGuests", Operator: repositories.OpIn, Value: []interface{}{2.0, 4.0}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := helper.ParseListQuery(contextFor(tt.target), tableSpec)
+			if err != nil {
+				t.Fatalf("parsing %s: %v", tt.target, err)
+			}
+			if !reflect.DeepEqual(query, tt.want) {
+				t.Fatalf("expected %+v, got %+v", tt.want, query)
+			}
+		})
+	}
+}
+
+func TestParseListQueryRejectsBadParameters(t *testing.T) {
+	targets := []string{
+		"/tables?limit=0",
+		"/tables?limit=ten",
+		"/tables?page=-1",
+		"/tables?sort=password",
+		"/tables?password=secret",
+		"/tables?tableNumber[like]=2",
+		"/tables?tableNumber=two",
+		"/tables?reserved=maybe",
+		"/tables?createdAt=yesterday",
+		"/tables?bad-key=1",
+	}
+
+	for _, target := range targets {
+		if _, err := helper.ParseListQuery(contextFor(target), tableSpec); err == nil {
+			t.Errorf("expected %s to be rejected", target)
+		}
+	}
+}
+
+func TestParseCursor(t *testing.T) {
+	cursor := repositories.Cursor{CreatedAt: time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC), ID: primitive.NewObjectID(), Backward: true}
+	encoded := helper.EncodeCursor(cursor)
+
+	parsed, err := helper.ParseCursor(contextFor("/orders?cursor=" + encoded))
+	if err != nil {
+		t.Fatalf("parsing an encoded cursor: %v", err)
+	}
+	if parsed == nil || !parsed.CreatedAt.Equal(cursor.CreatedAt) || parsed.ID != cursor.ID || !parsed.Backward {
+		t.Fatalf("expected the cursor to round-trip, got %+v", parsed)
+	}
+
+	if parsed, err := helper.ParseCursor(contextFor("/orders?paginate=cursor")); err != nil || parsed != nil {
+		t.Fatalf("expected no cursor for the first page, got %+v and %v", parsed, err)
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		err    error
+	}{
+		{"combined with page", "/orders?page=2&cursor=" + encoded, helper.ErrCursorWithPage},
+		{"combined with sort", "/orders?sort=orderDate&cursor=" + encoded, helper.ErrCursorWithPage},
+		{"page in cursor mode", "/orders?paginate=cursor&page=1", helper.ErrCursorWithPage},
+		{"not base64", "/orders?cursor=***", helper.ErrInvalidCursor},
+		{"not json", "/orders?cursor=bm90LWpzb24", helper.ErrInvalidCursor},
+		{"bad object id", "/orders?cursor=eyJ0IjoiMjAyNi0wMy0wNFQxMjowMDowMFoiLCJpZCI6Im5vcGUifQ", helper.ErrInvalidCursor},
+		{"missing timestamp", "/orders?cursor=eyJpZCI6IjY1ZjAwMDAwMDAwMDAwMDAwMDAwMDAwMCJ9", helper.ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := helper.ParseCursor(contextFor(tt.target)); !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestUsesCursor(t *testing.T) {
+	tests := map[string]bool{
+		"/orders":                 false,
+		"/orders?page=2":          false,
+		"/orders?paginate=page":   false,
+		"/orders?paginate=cursor": true,
+		"/orders?cursor=abc":      true,
+	}
+
+	for target, want := range tests {
+		if got := helper.UsesCursor(contextFor(target)); got != want {
+			t.Errorf("UsesCursor(%s) = %v, want %v", target, got, want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrIncludeDeletedForbidden = errors.New("includeDeleted requires the ADMIN role")
+
+func DeletedScope(ctx context.Context, c *gin.Context) (context.Context, error) {
+	if c.Query("includeDeleted") != "true" {
+		return ctx, nil
+	}
+	if c.GetString("role") != models.UserRoleAdmin {
+		return ctx, ErrIncludeDeletedForbidden
+	}
+	return repositories.WithDeleted(ctx), nil
+}
+
+func IsCascade(c *gin.Context) bool {
+	return c.Query("cascade") == "true"
+}
+
+func DeletedWith(child *time.Time, parent time.Time) bool {
+	return child != nil && child.Equal(parent)
+}
+
+func DeletionStamp(c *gin.Context) (time.Time, *string) {
+	deletedAt := time.Now().UTC()
+	uid := c.GetString("uid")
+	if uid == "" {
+		return deletedAt, nil
+	}
+	return deletedAt, &uid
+}
@@ -0,0 +1,31 @@
+package helpers
+
+import (
+	"context"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+)
+
+type RestaurantStats struct {
+	invoices repositories.InvoiceRepository
+}
+
+func NewRestaurantStats(invoices repositories.InvoiceRepository) *RestaurantStats {
+	return &RestaurantStats{invoices: invoices}
+}
+
+func (s *RestaurantStats) PendingInvoices(ctx context.Context) (int64, error) {
+	query := pendingInvoicesQuery()
+	query.Limit = 1
+
+	_, total, err := s.invoices.List(ctx, query)
+	return total, err
+}
+
+func (s *RestaurantStats) OpenTables(ctx context.Context) (int64, error) {
+	return s.invoices.CountOpenTables(ctx)
+}
+
+func pendingInvoicesQuery() repositories.Query {
+	return repositories.Query{}.Where("paymentStatus", repositories.OpEq, "PENDING")
+}
@@ -0,0 +1,211 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/config"
+)
+
+func TestCompose(t *testing.T) {
+	now := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+	data, err := compose("Restaurant <no-reply@restaurant.local>", Message{
+		To:      "jane@example.com",
+		Subject: "Reset\r\nBcc: attacker@example.com",
+		Body:    "Hello\nUse this link",
+	}, now)
+	if err != nil {
+		t.Fatalf("composing: %v", err)
+	}
+
+	headers, body, found := strings.Cut(string(data), "\r\n\r\n")
+	if !found {
+		t.Fatalf("expected a blank line between headers and body: %q", data)
+	}
+	for _, header := range []string{
+		"From: Restaurant <no-reply@restaurant.local>",
+		"To: jane@example.com",
+		"Subject: ResetBcc: attacker@example.com",
+		"Date: Wed, 04 Mar 2026 12:00:00 +0000",
+		"Content-Type: text/plain; charset=UTF-8",
+	} {
+		if !strings.Contains(headers+"\r\n", header+"\r\n") {
+			t.Errorf("expected header %q in %q", header, headers)
+		}
+	}
+	if strings.Contains(headers, "\r\nBcc:") {
+		t.Fatalf("expected the subject not to inject headers: %q", headers)
+	}
+	if body != "Hello\r\nUse this link" {
+		t.Fatalf("expected CRLF line endings in the body, got %q", body)
+	}
+}
+
+func TestComposeEncodesNonASCIISubjects(t *testing.T) {
+	data, err := compose("no-reply@restaurant.local", Message{To: "jane@example.com", Subject: "Café"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Subject: =?utf-8?q?Caf=C3=A9?=\r\n") {
+		t.Fatalf("expected a Q-encoded subject: %q", data)
+	}
+}
+
+func TestComposeRejectsInvalidRecipients(t *testing.T) {
+	if _, err := compose("no-reply@restaurant.local", Message{To: "not an address"}, time.Now()); err == nil {
+		t.Fatal("expected an invalid recipient to be rejected")
+	}
+}
+
+func TestNewMailerPicksTheDriver(t *testing.T) {
+	cfg := config.MailConfig{SMTPHost: "localhost", SMTPPort: 25, FileDir: t.TempDir()}
+
+	cfg.Driver = DriverSMTP
+	if _, ok := NewMailer(cfg).(*SMTPMailer); !ok {
+		t.Error("expected the smtp driver to build an SMTPMailer")
+	}
+	cfg.Driver = DriverFile
+	if _, ok := NewMailer(cfg).(*FileMailer); !ok {
+		t.Error("expected the file driver to build a FileMailer")
+	}
+	cfg.Driver = DriverLog
+	if _, ok := NewMailer(cfg).(*LogMailer); !ok {
+		t.Error("expected the log driver to build a LogMailer")
+	}
+}
+
+func TestFileMailerWritesOneFilePerMessage(t *testing.T) {
+	dir := t.TempDir()
+	mailer := NewFileMailer(dir, "no-reply@restaurant.local")
+
+	if err := mailer.Send(context.Background(), Message{To: "jane+test@example.com", Subject: "Welcome", Body: "Hi"}); err != nil {
+		t.Fatalf("sending: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || !strings.HasSuffix(entries[0].Name(), "-jane_test@example.com.eml") {
+		t.Fatalf("expected one sanitised .eml file, got %v", entries)
+	}
+
+	data, err := os.ReadFile(dir + "/" + entries[0].Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Subject: Welcome\r\n") || !strings.HasSuffix(string(data), "\r\n\r\nHi") {
+		t.Fatalf("unexpected message file: %q", data)
+	}
+}
+
+func TestSMTPMailerHonoursTheContext(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	// The server accepts the connection but never sends a greeting.
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(time.Second)
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	mailer := NewSMTPMailer("127.0.0.1", addr.Port, "", "", "no-reply@restaurant.local")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := mailer.Send(ctx, Message{To: "jane@example.com", Subject: "Hi"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the send to stop at the deadline, got %v", err)
+	}
+}
+
+func TestSMTPMailerRejectsAnInvalidSender(t *testing.T) {
+	mailer := NewSMTPMailer("127.0.0.1", 25, "", "", "not an address")
+	if err := mailer.Send(context.Background(), Message{To: "jane@example.com"}); err == nil {
+		t.Fatal("expected an invalid sender to be rejected")
+	}
+}
+
+type recordingMailer struct {
+	mu   sync.Mutex
+	sent []string
+}
+
+func (m *recordingMailer) Send(ctx context.Context, message Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, message.To)
+	return nil
+}
+
+func (m *recordingMailer) recipients() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string{}, m.sent...)
+}
+
+func TestQueueRejectsMessagesWhenFull(t *testing.T) {
+	queue := NewQueue(&recordingMailer{}, 1)
+
+	if err := queue.Send(context.Background(), Message{To: "first@example.com"}); err != nil {
+		t.Fatalf("expected the first message to be queued, got %v", err)
+	}
+	if err := queue.Send(context.Background(), Message{To: "second@example.com"}); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+}
+
+func TestQueueDeliversMessages(t *testing.T) {
+	mailer := &recordingMailer{}
+	queue := NewQueue(mailer, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ticks := make(chan struct{}, 10)
+	go queue.Run(ctx, func() { ticks <- struct{}{} })
+
+	if err := queue.Send(ctx, Message{To: "jane@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ticks:
+	case <-time.After(time.Second):
+		t.Fatal("expected a heartbeat after delivering a message")
+	}
+
+	if got := mailer.recipients(); len(got) != 1 || got[0] != "jane@example.com" {
+		t.Fatalf("expected the message to be delivered, got %v", got)
+	}
+}
+
+func TestQueueDrainsOnShutdown(t *testing.T) {
+	mailer := &recordingMailer{}
+	queue := NewQueue(mailer, 10)
+
+	for _, to := range []string{"first@example.com", "second@example.com", "third@example.com"} {
+		if err := queue.Send(context.Background(), Message{To: to}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	queue.Run(ctx, func() {})
+
+	if got := strings.Join(mailer.recipients(), ","); got != "first@example.com,second@example.com,third@example.com" {
+		t.Fatalf("expected every queued message to be delivered before returning, got %s", got)
+	}
+}
@@ -0,0 +1,76 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	sendTimeout       = 30 * time.Second
+	heartbeatInterval = 15 * time.Second
+)
+
+var ErrQueueFull = errors.New("mail queue is full")
+
+type Queue struct {
+	mailer   Mailer
+	messages chan Message
+}
+
+func NewQueue(mailer Mailer, size int) *Queue {
+	return &Queue{mailer: mailer, messages: make(chan Message, size)}
+}
+
+func (q *Queue) Send(ctx context.Context, message Message) error {
+	select {
+	case q.messages <- message:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+func (q *Queue) Run(ctx context.Context, tick func()) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			q.drain()
+			return
+		case message := <-q.messages:
+			q.deliver(message)
+			tick()
+		case <-ticker.C:
+			tick()
+		}
+	}
+}
+
+func (q *Queue) drain() {
+	for {
+		select {
+		case message := <-q.messages:
+			q.deliver(message)
+		default:
+			return
+		}
+	}
+}
+
+func (q *Queue) deliver(message Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+
+	if err := q.mailer.Send(ctx, message); err != nil {
+		utils.GetLogger().Error("Failed to send email",
+			zap.String("to", message.To),
+			zap.String("subject", message.Subject),
+			zap.Error(err))
+	}
+}
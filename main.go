@@ -10,48 +10,159 @@ import (
 	"time"
 
 	"github.com/datarohit/go-restaurant-management-backend-project/config"
+	"github.com/datarohit/go-restaurant-management-backend-project/controllers"
+	"github.com/datarohit/go-restaurant-management-backend-project/database"
+	"github.com/datarohit/go-restaurant-management-backend-project/health"
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/mailer"
+	"github.com/datarohit/go-restaurant-management-backend-project/metrics"
 	"github.com/datarohit/go-restaurant-management-backend-project/middlewares"
+	"github.com/datarohit/go-restaurant-management-backend-project/ratelimit"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
 	"github.com/datarohit/go-restaurant-management-backend-project/routes"
+	"github.com/datarohit/go-restaurant-management-backend-project/storage"
+	"github.com/datarohit/go-restaurant-management-backend-project/tracing"
 	"github.com/datarohit/go-restaurant-management-backend-project/utils"
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
 )
 
 func main() {
-	err := utils.InitializeLogger(zapcore.DebugLevel, []string{"stdout"})
+	cfg, err := config.Load()
 	if err != nil {
+		fmt.Printf("Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := utils.InitializeLogger(cfg.Logging); err != nil {
 		fmt.Printf("Error initializing logger: %v\n", err)
 		os.Exit(1)
 	}
 	log := utils.GetLogger()
 
-	port := config.GetEnvAsInt("PORT", 8080)
-	ginMode := config.GetEnv("GIN_MODE", "release")
+	if cfg.Auth.JWTSecret == config.DevelopmentJWTSecret {
+		log.Warn("Using the insecure development JWT secret; set JWT_SECRET before running in release mode")
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatal("Failed to initialize tracing", zap.Error(err))
+	}
+
+	client, err := database.Connect(cfg.Mongo)
+	if err != nil {
+		log.Fatal("Failed to create MongoDB client", zap.Error(err))
+	}
+	db := client.Database(cfg.Mongo.Database)
+
+	if len(os.Args) > 1 {
+		err := runCommand(os.Args[1:], db, cfg)
 
-	gin.SetMode(ginMode)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		database.Disconnect(ctx)
+		shutdownTracing(ctx)
+
+		if err != nil {
+			log.Fatal("Command failed", zap.String("command", os.Args[1]), zap.Error(err))
+		}
+		return
+	}
+
+	if cfg.Migrations.OnStartup {
+		if err := migrate(db, cfg); err != nil {
+			log.Fatal("Failed to run migrations", zap.Error(err))
+		}
+	}
+
+	gin.SetMode(cfg.Server.Mode)
 	router := gin.New()
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatal("Invalid trusted proxies", zap.Error(err))
+	}
 
+	router.Use(middlewares.Tracing(cfg.Tracing.ServiceName))
+	router.Use(middlewares.RequestID(log))
 	router.Use(middlewares.ZapLoggerMiddleware(log))
-	router.Use(gin.Recovery())
-
-	routes.HealthRoutes(router)
-	routes.UserRoutes(router)
+	router.Use(middlewares.Metrics())
+	router.Use(middlewares.Recovery())
+	router.Use(middlewares.AuditActor())
+	router.NoRoute(middlewares.NoRoute())
+
+	repos := repositories.NewMongoRepositories(db)
+	location, _ := cfg.Restaurant.Location()
+	tokens := helper.NewTokenManager(cfg.Auth)
+	evaluator := helper.NewPromotionEvaluator(repos.OrderItems, repos.Foods, repos.Menus, repos.Promotions, location)
+
+	if err := metrics.RegisterBusinessStats(helper.NewRestaurantStats(repos.Invoices)); err != nil {
+		log.Fatal("Failed to register business metrics", zap.Error(err))
+	}
 
-	router.Use(middlewares.Authentication())
+	checks := health.NewRegistry(cfg.Health.CheckTimeout)
+	checks.Register("mongo", health.Readiness, database.Check)
+	checks.Register("migrations", health.Readiness, health.MigrationsCheck(db))
+	if cfg.Storage.Driver == "local" {
+		checks.Register("disk", health.Readiness, health.DiskSpaceCheck(cfg.Storage.LocalPath, cfg.Health.MinFreeDiskMB<<20))
+	}
 
-	routes.MenuRoutes(router)
-	routes.FoodRoutes(router)
-	routes.TableRoutes(router)
-	routes.OrderRoutes(router)
-	routes.OrderItemRoutes(router)
-	routes.InvoiceRoutes(router)
+	workers, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	authLimits, apiLimits := rateLimits(workers, cfg, db, checks)
+
+	mail := mailer.NewQueue(mailer.NewMailer(cfg.Mail), cfg.Mail.QueueSize)
+	go mail.Run(workers, checks.Heartbeat("mail-queue", time.Minute).Beat)
+	accounts := helper.NewAccountMailer(repos.UserTokens, mail, cfg.Mail, cfg.Auth)
+	go helper.RunPriceScheduler(workers, repos.Foods, repos.FoodPrices, time.Minute, checks.Heartbeat("price-scheduler", 3*time.Minute).Beat)
+
+	healthController := controllers.NewHealthController(checks)
+	userController := controllers.NewUserController(repos.Users, tokens, helper.NewLoginLockout(repos.Users, cfg.Auth), accounts, cfg.Auth.RequireVerifiedEmail)
+	menuController := controllers.NewMenuController(repos.Menus, repos.Foods, repos.Bundles)
+	foodController := controllers.NewFoodController(repos.Foods, repos.Menus, repos.FoodPrices, repos.Bundles)
+	foodPriceController := controllers.NewFoodPriceController(repos.Foods, repos.FoodPrices)
+	imageController := controllers.NewImageController(repos.Foods, storage.NewBlobStore(client, cfg.Mongo.Database, cfg.Storage), int64(cfg.Storage.MaxUploadSizeMB)<<20)
+	tableController := controllers.NewTableController(repos.Tables)
+	orderController := controllers.NewOrderController(repos.Orders, repos.Tables, repos.OrderItems, repos.Foods, repos.Invoices)
+	orderItemController := controllers.NewOrderItemController(repos.Orders, repos.OrderItems, repos.Foods, repos.FoodPrices, repos.Bundles, repos.Invoices, evaluator)
+	invoiceController := controllers.NewInvoiceController(repos.Invoices, repos.Orders, repos.Tables, repos.OrderItems, evaluator)
+	promotionController := controllers.NewPromotionController(repos.Promotions, repos.Orders, evaluator)
+	bundleController := controllers.NewBundleController(repos.Bundles, repos.Foods, repos.Menus)
+	auditController := controllers.NewAuditController(repos.AuditLogs)
+	loggingController := controllers.NewLoggingController(utils.LogLevel())
+
+	routes.HealthRoutes(router, healthController)
+	routes.UserRoutes(router, userController, authLimits...)
+	routes.GuestRoutes(router, foodController)
+	routes.ImageRoutes(router, imageController)
+
+	router.Use(middlewares.Authentication(tokens, repos.Users))
+	router.Use(apiLimits...)
+
+	routes.ProfileRoutes(router, userController)
+	routes.MenuRoutes(router, menuController)
+	routes.FoodRoutes(router, foodController, foodPriceController, imageController)
+	routes.TableRoutes(router, tableController)
+	routes.OrderRoutes(router, orderController)
+	routes.OrderItemRoutes(router, orderItemController)
+	routes.InvoiceRoutes(router, invoiceController)
+	routes.PromotionRoutes(router, promotionController)
+	routes.BundleRoutes(router, bundleController)
+	routes.AuditRoutes(router, auditController)
+	routes.AdminRoutes(router, loggingController)
 
 	server := &http.Server{
-		Addr:    fmt.Sprintf(":%d", port),
+		Addr:    fmt.Sprintf(":%d", cfg.Server.Port),
 		Handler: router,
 	}
 
+	metricsRouter := gin.New()
+	metricsRouter.Use(middlewares.Recovery())
+	routes.MetricsRoutes(metricsRouter)
+	metricsServer := &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.Server.MetricsPort),
+		Handler: metricsRouter,
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
@@ -60,17 +171,58 @@ func main() {
 			log.Fatal("Server failed to start", zap.Error(err))
 		}
 	}()
-	log.Info(fmt.Sprintf("Server is running on port %d", port))
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal("Metrics server failed to start", zap.Error(err))
+		}
+	}()
+	log.Info(fmt.Sprintf("Server is running on port %d", cfg.Server.Port))
+	log.Info(fmt.Sprintf("Metrics are served on port %d", cfg.Server.MetricsPort))
 
 	<-quit
 	log.Info("Shutdown signal received, exiting gracefully...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	stopWorkers()
+	checks.SetShuttingDown()
+	time.Sleep(cfg.Health.ShutdownDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatal("Server forced to shutdown", zap.Error(err))
 	}
 
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		log.Error("Error shutting down the metrics server", zap.Error(err))
+	}
+
+	if err := database.Disconnect(ctx); err != nil {
+		log.Error("Error disconnecting from MongoDB", zap.Error(err))
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		log.Error("Error flushing traces", zap.Error(err))
+	}
+
 	log.Info("Server exited cleanly")
+	_ = utils.SyncLogger()
+}
+
+func rateLimits(ctx context.Context, cfg *config.Config, db *mongo.Database, checks *health.Registry) ([]gin.HandlerFunc, []gin.HandlerFunc) {
+	if !cfg.RateLimit.Enabled {
+		return nil, nil
+	}
+
+	store := ratelimit.NewStore(db, cfg.RateLimit)
+	if memory, ok := store.(*ratelimit.MemoryStore); ok {
+		heartbeat := checks.Heartbeat("rate-limit-sweeper", 3*time.Minute)
+		go memory.Run(ctx, time.Minute, heartbeat.Beat)
+	}
+
+	auth := ratelimit.NewLimiter(store, "auth", ratelimit.PerMinute(cfg.RateLimit.AuthPerMinute, cfg.RateLimit.AuthBurst))
+	api := ratelimit.NewLimiter(store, "api", ratelimit.PerMinute(cfg.RateLimit.APIPerMinute, cfg.RateLimit.APIBurst))
+
+	return []gin.HandlerFunc{middlewares.RateLimit(auth, middlewares.ByClientIP, middlewares.ByAccount)},
+		[]gin.HandlerFunc{middlewares.RateLimit(api, middlewares.ByAccount)}
 }
@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+type BusinessStats interface {
+	OpenTables(ctx context.Context) (int64, error)
+	PendingInvoices(ctx context.Context) (int64, error)
+}
+
+type businessCollector struct {
+	stats           BusinessStats
+	openTables      *prometheus.Desc
+	pendingInvoices *prometheus.Desc
+}
+
+func RegisterBusinessStats(stats BusinessStats) error {
+	return Registry.Register(&businessCollector{
+		stats:           stats,
+		openTables:      prometheus.NewDesc(namespace+"_open_tables", "Tables with an unpaid invoice.", nil, nil),
+		pendingInvoices: prometheus.NewDesc(namespace+"_pending_invoices", "Invoices whose payment is still pending.", nil, nil),
+	})
+}
+
+func (b *businessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- b.openTables
+	ch <- b.pendingInvoices
+}
+
+func (b *businessCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if count, err := b.stats.OpenTables(ctx); err != nil {
+		utils.GetLogger().Warn("Failed to collect open tables", zap.Error(err))
+	} else {
+		ch <- prometheus.MustNewConstMetric(b.openTables, prometheus.GaugeValue, float64(count))
+	}
+
+	if count, err := b.stats.PendingInvoices(ctx); err != nil {
+		utils.GetLogger().Warn("Failed to collect pending invoices", zap.Error(err))
+	} else {
+		ch <- prometheus.MustNewConstMetric(b.pendingInvoices, prometheus.GaugeValue, float64(count))
+	}
+}
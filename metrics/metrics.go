@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "restaurant"
+
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests handled, by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency, by method, route template and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	httpInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "HTTP requests currently being served.",
+	})
+
+	httpRateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_rate_limited_total",
+		Help: "HTTP requests rejected by a rate limiter, by limiter.",
+	}, []string{"limiter"})
+
+	ordersPlaced = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "orders_placed_total",
+		Help:      "Orders placed since the process started.",
+	})
+
+	invoicesPaid = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "invoices_paid_total",
+		Help:      "Invoices marked as paid since the process started.",
+	})
+
+	revenue = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "revenue_total",
+		Help:      "Sum of the totals of paid invoices since the process started.",
+	})
+
+	jobRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "background_job_runs_total",
+		Help: "Runs of background jobs, by job and outcome.",
+	}, []string{"job", "outcome"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		httpInFlight,
+		httpRateLimited,
+		mongoCommandDuration,
+		mongoPoolConnections,
+		mongoPoolConnectionsInUse,
+		mongoPoolEvents,
+		ordersPlaced,
+		invoicesPaid,
+		revenue,
+		jobRuns,
+	)
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+func RequestStarted() {
+	httpInFlight.Inc()
+}
+
+func RateLimited(limiter string) {
+	httpRateLimited.WithLabelValues(limiter).Inc()
+}
+
+func RequestFinished(method, route string, status int, duration time.Duration) {
+	httpInFlight.Dec()
+
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+func OrderPlaced() {
+	ordersPlaced.Inc()
+}
+
+func InvoicePaid(total float64) {
+	invoicesPaid.Inc()
+	if total > 0 {
+		revenue.Add(total)
+	}
+}
+
+func JobRun(job string, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+	}
+	jobRuns.WithLabelValues(job, outcome).Inc()
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+var (
+	mongoCommandDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mongodb_command_duration_seconds",
+		Help:    "MongoDB command latency, by command name and outcome.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"command", "outcome"})
+
+	mongoPoolConnections = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "mongodb_pool_connections",
+		Help: "Open connections in the MongoDB connection pool.",
+	})
+
+	mongoPoolConnectionsInUse = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "mongodb_pool_connections_in_use",
+		Help: "MongoDB connections currently checked out of the pool.",
+	})
+
+	mongoPoolEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mongodb_pool_events_total",
+		Help: "MongoDB connection pool events, by event type.",
+	}, []string{"event"})
+)
+
+func CommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			mongoCommandDuration.WithLabelValues(e.CommandName, "success").Observe(e.Duration.Seconds())
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			mongoCommandDuration.WithLabelValues(e.CommandName, "failure").Observe(e.Duration.Seconds())
+		},
+	}
+}
+
+func PoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				mongoPoolConnections.Inc()
+			case event.ConnectionClosed:
+				mongoPoolConnections.Dec()
+			case event.GetSucceeded:
+				mongoPoolConnectionsInUse.Inc()
+			case event.ConnectionReturned:
+				mongoPoolConnectionsInUse.Dec()
+			}
+			mongoPoolEvents.WithLabelValues(e.Type).Inc()
+		},
+	}
+}
@@ -0,0 +1,15 @@
+package middlewares
+
+import (
+	"github.com/datarohit/go-restaurant-management-backend-project/audit"
+	"github.com/gin-gonic/gin"
+)
+
+func AuditActor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := audit.Actor{ClientIP: c.ClientIP()}
+		c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), actor))
+
+		c.Next()
+	}
+}
@@ -1,40 +1,52 @@
 package middlewares
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"net/http"
+	"time"
 
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
+	"github.com/datarohit/go-restaurant-management-backend-project/audit"
 	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
-	"github.com/dgrijalva/jwt-go"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
-func Authentication() gin.HandlerFunc {
+func Authentication(tokens *helper.TokenManager, users repositories.UserRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		clientToken := c.GetHeader("Authorization")
 		if clientToken == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is missing"})
-			c.Abort()
+			apierrors.Write(c, http.StatusUnauthorized, "Authorization header is missing")
 			return
 		}
 
-		token, err := jwt.ParseWithClaims(clientToken, &helper.SignedDetails{}, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return []byte(helper.JWT_SECRET), nil
-		})
-
+		claims, err := tokens.ParseToken(clientToken)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
-			c.Abort()
+			apierrors.Write(c, http.StatusUnauthorized, "Invalid or expired token")
 			return
 		}
 
-		claims, ok := token.Claims.(*helper.SignedDetails)
-		if !ok || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
-			c.Abort()
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+		user, err := users.FindByID(ctx, claims.UID)
+		cancel()
+		if errors.Is(err, repositories.ErrNotFound) {
+			apierrors.Write(c, http.StatusUnauthorized, "The account for this token no longer exists")
+			return
+		} else if err != nil {
+			apierrors.Internal(c, "Error loading the authenticated user", err)
+			return
+		}
+
+		if user.Deactivated {
+			apierrors.New(http.StatusUnauthorized, "This account has been deactivated").WithCode("account_deactivated").Send(c)
+			return
+		}
+		if helper.RevokedBefore(*user, claims) || !helper.IsCurrentToken(*user, clientToken) {
+			apierrors.New(http.StatusUnauthorized, "This token has been revoked; log in again").WithCode("token_revoked").Send(c)
 			return
 		}
 
@@ -42,6 +54,14 @@ func Authentication() gin.HandlerFunc {
 		c.Set("firstName", claims.FirstName)
 		c.Set("lastName", claims.LastName)
 		c.Set("uid", claims.UID)
+		c.Set("role", helper.GetNonNilString(user.Role, models.UserRoleStaff))
+
+		actor := audit.Actor{UserID: claims.UID, Email: claims.Email, ClientIP: c.ClientIP()}
+		ctx = audit.WithActor(c.Request.Context(), actor)
+
+		requestLogger := utils.LoggerFrom(ctx).With(zap.String("uid", claims.UID))
+		c.Set("logger", requestLogger)
+		c.Request = c.Request.WithContext(utils.WithLogger(ctx, requestLogger))
 
 		c.Next()
 	}
@@ -13,8 +13,13 @@ func ZapLoggerMiddleware(logger *zap.Logger) gin.HandlerFunc {
 
 		c.Next()
 
+		requestLogger := logger
+		if l, ok := c.Get("logger"); ok {
+			requestLogger = l.(*zap.Logger)
+		}
+
 		duration := time.Since(start)
-		logger.Info("HTTP Request",
+		requestLogger.Info("HTTP Request",
 			zap.String("method", c.Request.Method),
 			zap.String("path", c.Request.URL.Path),
 			zap.String("ip", c.ClientIP()),
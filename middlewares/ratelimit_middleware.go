@@ -0,0 +1,98 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
+	"github.com/datarohit/go-restaurant-management-backend-project/metrics"
+	"github.com/datarohit/go-restaurant-management-backend-project/ratelimit"
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const maxPeekedBodySize = 1 << 16
+
+type RateLimitKey func(c *gin.Context) string
+
+func ByClientIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+func ByAccount(c *gin.Context) string {
+	if uid := c.GetString("uid"); uid != "" {
+		return "user:" + uid
+	}
+	if email := peekEmail(c); email != "" {
+		return "email:" + email
+	}
+	return ""
+}
+
+func RateLimit(limiter *ratelimit.Limiter, keys ...RateLimitKey) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var tightest, limited *ratelimit.Decision
+		for _, key := range keys {
+			value := key(c)
+			if value == "" {
+				continue
+			}
+
+			decision, err := limiter.Allow(c.Request.Context(), value)
+			if err != nil {
+				utils.LoggerFrom(c.Request.Context()).Warn("Rate limiter unavailable, allowing request",
+					zap.String("limiter", limiter.Name()),
+					zap.Error(err))
+				continue
+			}
+
+			if tightest == nil || decision.Remaining < tightest.Remaining {
+				tightest = &decision
+			}
+			if !decision.Allowed && (limited == nil || decision.RetryAfter > limited.RetryAfter) {
+				limited = &decision
+			}
+		}
+
+		if tightest != nil {
+			c.Header("X-RateLimit-Limit", strconv.Itoa(tightest.Limit))
+			c.Header("X-RateLimit-Remaining", strconv.Itoa(tightest.Remaining))
+		}
+
+		if limited != nil {
+			metrics.RateLimited(limiter.Name())
+			problem := apierrors.New(http.StatusTooManyRequests, "Too many requests; slow down and retry later")
+			apierrors.RetryLater(c, problem, limited.RetryAfter)
+			return
+		}
+		c.Next()
+	}
+}
+
+func peekEmail(c *gin.Context) string {
+	if c.Request.Body == nil || !strings.HasPrefix(c.ContentType(), "application/json") {
+		return ""
+	}
+
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxPeekedBodySize))
+	c.Request.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), c.Request.Body), c.Request.Body}
+	if err != nil {
+		return ""
+	}
+
+	var body struct {
+		Email string `json:"email"`
+	}
+	if json.Unmarshal(data, &body) != nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(body.Email))
+}
@@ -0,0 +1,27 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
+		utils.LoggerFrom(c.Request.Context()).Error("Recovered from panic",
+			zap.Any("panic", recovered),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Stack("stack"))
+		apierrors.Write(c, http.StatusInternalServerError, "An unexpected error occurred")
+	})
+}
+
+func NoRoute() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apierrors.Write(c, http.StatusNotFound, "No route matches "+c.Request.Method+" "+c.Request.URL.Path)
+	}
+}
@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"crypto/rand"
+	"fmt"
+	"regexp"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+func RequestID(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id = newRequestID()
+		}
+
+		c.Set("requestId", id)
+		c.Header(RequestIDHeader, id)
+
+		requestLogger := logger.With(zap.String("requestId", id))
+		if span := trace.SpanFromContext(c.Request.Context()); span.SpanContext().IsValid() {
+			span.SetAttributes(attribute.String("request.id", id))
+			requestLogger = requestLogger.With(zap.String("traceId", span.SpanContext().TraceID().String()))
+		}
+		c.Set("logger", requestLogger)
+		c.Request = c.Request.WithContext(utils.WithLogger(c.Request.Context(), requestLogger))
+
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/apierrors"
+	"github.com/gin-gonic/gin"
+)
+
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		apierrors.Write(c, http.StatusForbidden, "You do not have permission to access this resource")
+	}
+}
@@ -0,0 +1,88 @@
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func renameOrderItemUnitPrice(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("orderItem").UpdateMany(ctx,
+		bson.M{"unitprice": bson.M{"$exists": true}},
+		mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{"unitPrice": "$unitprice"}}},
+			{{Key: "$unset", Value: "unitprice"}},
+		},
+	)
+	return err
+}
+
+func backfillDocumentVersions(ctx context.Context, db *mongo.Database) error {
+	collections := []string{"user", "menu", "food", "foodPrice", "table", "order", "orderItem", "invoice", "promotion", "bundle"}
+	for _, collection := range collections {
+		if _, err := db.Collection(collection).UpdateMany(ctx,
+			bson.M{"version": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"version": 1}},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func backfillEmailVerified(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("user").UpdateMany(ctx,
+		bson.M{"emailVerified": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"emailVerified": true}},
+	)
+	return err
+}
+
+func hashStoredTokens(ctx context.Context, db *mongo.Database) error {
+	users := db.Collection("user")
+
+	cursor, err := users.Find(ctx, bson.M{"$or": bson.A{
+		bson.M{"accessToken": bson.M{"$exists": true}},
+		bson.M{"refreshToken": bson.M{"$exists": true}},
+	}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var user struct {
+			ID           interface{} `bson:"_id"`
+			AccessToken  *string     `bson:"accessToken"`
+			RefreshToken *string     `bson:"refreshToken"`
+		}
+		if err := cursor.Decode(&user); err != nil {
+			return err
+		}
+
+		set := bson.M{}
+		if user.AccessToken != nil {
+			set["accessTokenHash"] = sha256Hex(*user.AccessToken)
+		}
+		if user.RefreshToken != nil {
+			set["refreshTokenHash"] = sha256Hex(*user.RefreshToken)
+		}
+
+		update := bson.M{"$unset": bson.M{"accessToken": "", "refreshToken": ""}}
+		if len(set) > 0 {
+			update["$set"] = set
+		}
+		if _, err := users.UpdateByID(ctx, user.ID, update); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func sha256Hex(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
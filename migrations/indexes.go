@@ -0,0 +1,114 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func uniqueIndex(name string, keys bson.D) mongo.IndexModel {
+	return mongo.IndexModel{Keys: keys, Options: options.Index().SetUnique(true).SetName(name)}
+}
+
+func index(name string, keys bson.D) mongo.IndexModel {
+	return mongo.IndexModel{Keys: keys, Options: options.Index().SetName(name)}
+}
+
+func createAuditLogIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("audit_log").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		uniqueIndex("auditLogId_unique", bson.D{{Key: "auditLogId", Value: 1}}),
+		index("resourceType_resourceId_timestamp", bson.D{{Key: "resourceType", Value: 1}, {Key: "resourceId", Value: 1}, {Key: "timestamp", Value: -1}}),
+		index("userId_timestamp", bson.D{{Key: "userId", Value: 1}, {Key: "timestamp", Value: -1}}),
+		index("timestamp", bson.D{{Key: "timestamp", Value: -1}}),
+	})
+	return err
+}
+
+func createKeysetIndexes(ctx context.Context, db *mongo.Database) error {
+	for _, collection := range []string{"order", "orderItem", "invoice"} {
+		if _, err := db.Collection(collection).Indexes().CreateOne(ctx,
+			index("createdAt_id", bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}),
+		); err != nil {
+			return fmt.Errorf("creating keyset index on %s: %w", collection, err)
+		}
+	}
+	return nil
+}
+
+func createRateLimitIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("rate_limits").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0).SetName("expiresAt_ttl"),
+	})
+	return err
+}
+
+func createUserTokenIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("userToken").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		uniqueIndex("userTokenId_unique", bson.D{{Key: "userTokenId", Value: 1}}),
+		uniqueIndex("tokenHash_unique", bson.D{{Key: "tokenHash", Value: 1}}),
+		index("userId_purpose", bson.D{{Key: "userId", Value: 1}, {Key: "purpose", Value: 1}}),
+		{
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0).SetName("expiresAt_ttl"),
+		},
+	})
+	return err
+}
+
+func createIndexes(ctx context.Context, db *mongo.Database) error {
+	collectionIndexes := map[string][]mongo.IndexModel{
+		"user": {
+			uniqueIndex("userId_unique", bson.D{{Key: "userId", Value: 1}}),
+			uniqueIndex("email_unique", bson.D{{Key: "email", Value: 1}}),
+			uniqueIndex("phone_unique", bson.D{{Key: "phone", Value: 1}}),
+		},
+		"menu": {
+			uniqueIndex("menuId_unique", bson.D{{Key: "menuId", Value: 1}}),
+		},
+		"food": {
+			uniqueIndex("foodId_unique", bson.D{{Key: "foodId", Value: 1}}),
+			index("menuId_name", bson.D{{Key: "menuId", Value: 1}, {Key: "name", Value: 1}}),
+		},
+		"foodPrice": {
+			uniqueIndex("foodPriceId_unique", bson.D{{Key: "foodPriceId", Value: 1}}),
+			index("foodId_effectiveFrom", bson.D{{Key: "foodId", Value: 1}, {Key: "effectiveFrom", Value: -1}}),
+		},
+		"table": {
+			uniqueIndex("tableId_unique", bson.D{{Key: "tableId", Value: 1}}),
+			index("tableNumber", bson.D{{Key: "tableNumber", Value: 1}}),
+		},
+		"order": {
+			uniqueIndex("orderId_unique", bson.D{{Key: "orderId", Value: 1}}),
+			index("tableId_orderDate", bson.D{{Key: "tableId", Value: 1}, {Key: "orderDate", Value: -1}}),
+		},
+		"orderItem": {
+			uniqueIndex("orderItemId_unique", bson.D{{Key: "orderItemId", Value: 1}}),
+			index("orderId_createdAt", bson.D{{Key: "orderId", Value: 1}, {Key: "createdAt", Value: 1}}),
+			index("foodId", bson.D{{Key: "foodId", Value: 1}}),
+		},
+		"invoice": {
+			uniqueIndex("invoiceId_unique", bson.D{{Key: "invoiceId", Value: 1}}),
+			index("orderId", bson.D{{Key: "orderId", Value: 1}}),
+			index("paymentStatus_paymentDueDate", bson.D{{Key: "paymentStatus", Value: 1}, {Key: "paymentDueDate", Value: 1}}),
+		},
+		"promotion": {
+			uniqueIndex("promotionId_unique", bson.D{{Key: "promotionId", Value: 1}}),
+			index("active_startDate_endDate", bson.D{{Key: "active", Value: 1}, {Key: "startDate", Value: 1}, {Key: "endDate", Value: 1}}),
+		},
+		"bundle": {
+			uniqueIndex("bundleId_unique", bson.D{{Key: "bundleId", Value: 1}}),
+			index("menuId", bson.D{{Key: "menuId", Value: 1}}),
+		},
+	}
+
+	for collection, indexes := range collectionIndexes {
+		if _, err := db.Collection(collection).Indexes().CreateMany(ctx, indexes); err != nil {
+			return fmt.Errorf("creating indexes on %s: %w", collection, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const migrationsCollection = "schema_migrations"
+
+var ErrMigrationInProgress = errors.New("migration is already being applied by another process")
+
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+type AppliedMigration struct {
+	Version     int       `json:"version" bson:"version"`
+	Description string    `json:"description" bson:"description"`
+	AppliedAt   time.Time `json:"appliedAt" bson:"appliedAt"`
+}
+
+type MigrationStatus struct {
+	Version     int        `json:"version"`
+	Description string     `json:"description"`
+	Applied     bool       `json:"applied"`
+	AppliedAt   *time.Time `json:"appliedAt,omitempty"`
+}
+
+func All() []Migration {
+	migrations := []Migration{
+		{Version: 1, Description: "create indexes for all collections", Up: createIndexes},
+		{Version: 2, Description: "rename orderItem unitprice field to unitPrice", Up: renameOrderItemUnitPrice},
+		{Version: 3, Description: "create audit_log indexes", Up: createAuditLogIndexes},
+		{Version: 4, Description: "backfill document versions for optimistic concurrency", Up: backfillDocumentVersions},
+		{Version: 5, Description: "create createdAt/_id indexes for cursor pagination", Up: createKeysetIndexes},
+		{Version: 6, Description: "create rate_limits TTL index", Up: createRateLimitIndexes},
+		{Version: 7, Description: "create userToken indexes", Up: createUserTokenIndexes},
+		{Version: 8, Description: "mark existing users as email verified", Up: backfillEmailVerified},
+		{Version: 9, Description: "replace stored access and refresh tokens with their hashes", Up: hashStoredTokens},
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations
+}
+
+func Run(ctx context.Context, db *mongo.Database) (int, error) {
+	log := utils.GetLogger()
+	collection := db.Collection(migrationsCollection)
+
+	if _, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "version", Value: 1}},
+		Options: options.Index().SetUnique(true).SetName("version_unique"),
+	}); err != nil {
+		return 0, fmt.Errorf("creating %s index: %w", migrationsCollection, err)
+	}
+
+	applied, err := appliedVersions(ctx, collection)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, migration := range All() {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		log.Info("Applying migration",
+			zap.Int("version", migration.Version),
+			zap.String("description", migration.Description))
+
+		if err := migration.Up(ctx, db); err != nil {
+			return count, fmt.Errorf("migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+
+		record := AppliedMigration{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now().UTC(),
+		}
+		if _, err := collection.InsertOne(ctx, record); mongo.IsDuplicateKeyError(err) {
+			return count, fmt.Errorf("migration %d: %w", migration.Version, ErrMigrationInProgress)
+		} else if err != nil {
+			return count, fmt.Errorf("recording migration %d: %w", migration.Version, err)
+		}
+
+		count++
+	}
+
+	log.Info("Migrations complete", zap.Int("applied", count))
+	return count, nil
+}
+
+func Status(ctx context.Context, db *mongo.Database) ([]MigrationStatus, error) {
+	applied, err := appliedVersions(ctx, db.Collection(migrationsCollection))
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []MigrationStatus{}
+	for _, migration := range All() {
+		status := MigrationStatus{Version: migration.Version, Description: migration.Description}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func appliedVersions(ctx context.Context, collection *mongo.Collection) (map[int]AppliedMigration, error) {
+	cursor, err := collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", migrationsCollection, err)
+	}
+	defer cursor.Close(ctx)
+
+	var records []AppliedMigration
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", migrationsCollection, err)
+	}
+
+	applied := make(map[int]AppliedMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
@@ -0,0 +1,130 @@
+package migrations_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/migrations"
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestAllIsOrderedAndComplete(t *testing.T) {
+	for i, migration := range migrations.All() {
+		if migration.Version != i+1 {
+			t.Fatalf("expected version %d at position %d, got %d", i+1, i, migration.Version)
+		}
+		if migration.Description == "" || migration.Up == nil {
+			t.Fatalf("migration %d needs a description and an Up function", migration.Version)
+		}
+	}
+}
+
+// appliedCursor answers the schema_migrations read with every version except
+// the pending ones.
+func appliedCursor(pending ...int) bson.D {
+	var docs []bson.D
+	for _, migration := range migrations.All() {
+		skip := false
+		for _, version := range pending {
+			skip = skip || migration.Version == version
+		}
+		if !skip {
+			docs = append(docs, bson.D{{Key: "version", Value: migration.Version}, {Key: "description", Value: migration.Description}})
+		}
+	}
+	return mtest.CreateCursorResponse(0, "restaurant.schema_migrations", mtest.FirstBatch, docs...)
+}
+
+func TestRun(t *testing.T) {
+	utils.LogLevel().SetLevel(zapcore.ErrorLevel)
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	// Version 6 only creates the rate_limits TTL index, so it needs a single response.
+	const pending = 6
+
+	mt.Run("skips applied migrations", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), appliedCursor())
+
+		count, err := migrations.Run(context.Background(), mt.DB)
+		if err != nil || count != 0 {
+			mt.Fatalf("expected nothing to apply, got %d and %v", count, err)
+		}
+		if len(mt.GetAllStartedEvents()) != 2 {
+			mt.Fatalf("expected only the index and the read, got %d commands", len(mt.GetAllStartedEvents()))
+		}
+	})
+
+	mt.Run("applies and records pending migrations", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(), appliedCursor(pending), mtest.CreateSuccessResponse(), mtest.CreateSuccessResponse())
+
+		count, err := migrations.Run(context.Background(), mt.DB)
+		if err != nil || count != 1 {
+			mt.Fatalf("expected one migration to apply, got %d and %v", count, err)
+		}
+
+		events := mt.GetAllStartedEvents()
+		if len(events) != 4 {
+			mt.Fatalf("expected 4 commands, got %d", len(events))
+		}
+		if collection := events[2].Command.Lookup("createIndexes").StringValue(); collection != "rate_limits" {
+			mt.Fatalf("expected the rate_limits migration to run, got %s", collection)
+		}
+		record := events[3].Command.Lookup("documents").Array().Index(0).Value().Document()
+		if version := record.Lookup("version").AsInt64(); version != pending {
+			mt.Fatalf("expected version %d to be recorded, got %d", pending, version)
+		}
+	})
+
+	mt.Run("stops when a migration fails", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(),
+			appliedCursor(pending),
+			mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 85, Name: "IndexOptionsConflict", Message: "index options conflict"}),
+		)
+
+		count, err := migrations.Run(context.Background(), mt.DB)
+		if err == nil || count != 0 || !strings.Contains(err.Error(), "migration 6") {
+			mt.Fatalf("expected migration 6 to fail, got %d and %v", count, err)
+		}
+	})
+
+	mt.Run("detects a concurrent run", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(),
+			appliedCursor(pending),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateWriteErrorsResponse(mtest.WriteError{Index: 0, Code: 11000, Message: "E11000 duplicate key error"}),
+		)
+
+		if _, err := migrations.Run(context.Background(), mt.DB); !errors.Is(err, migrations.ErrMigrationInProgress) {
+			mt.Fatalf("expected ErrMigrationInProgress, got %v", err)
+		}
+	})
+}
+
+func TestStatus(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("reports applied and pending migrations", func(mt *mtest.T) {
+		mt.AddMockResponses(appliedCursor(8, 9))
+
+		statuses, err := migrations.Status(context.Background(), mt.DB)
+		if err != nil {
+			mt.Fatal(err)
+		}
+		if len(statuses) != len(migrations.All()) {
+			mt.Fatalf("expected a status per migration, got %d", len(statuses))
+		}
+		for _, status := range statuses {
+			pending := status.Version == 8 || status.Version == 9
+			if status.Applied == pending || (status.AppliedAt == nil) == status.Applied {
+				mt.Fatalf("unexpected status %+v", status)
+			}
+		}
+	})
+}
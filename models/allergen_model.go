@@ -0,0 +1,66 @@
+package models
+
+const (
+	AllergenCelery      = "CELERY"
+	AllergenGluten      = "GLUTEN"
+	AllergenCrustaceans = "CRUSTACEANS"
+	AllergenEggs        = "EGGS"
+	AllergenFish        = "FISH"
+	AllergenLupin       = "LUPIN"
+	AllergenMilk        = "MILK"
+	AllergenMolluscs    = "MOLLUSCS"
+	AllergenMustard     = "MUSTARD"
+	AllergenTreeNuts    = "TREE_NUTS"
+	AllergenPeanuts     = "PEANUTS"
+	AllergenSesame      = "SESAME"
+	AllergenSoybeans    = "SOYBEANS"
+	AllergenSulphites   = "SULPHITES"
+)
+
+const (
+	DietaryVegan      = "VEGAN"
+	DietaryVegetarian = "VEGETARIAN"
+	DietaryHalal      = "HALAL"
+	DietaryGlutenFree = "GLUTEN_FREE"
+)
+
+var Allergens = []string{
+	AllergenCelery,
+	AllergenGluten,
+	AllergenCrustaceans,
+	AllergenEggs,
+	AllergenFish,
+	AllergenLupin,
+	AllergenMilk,
+	AllergenMolluscs,
+	AllergenMustard,
+	AllergenTreeNuts,
+	AllergenPeanuts,
+	AllergenSesame,
+	AllergenSoybeans,
+	AllergenSulphites,
+}
+
+var DietaryFlags = []string{
+	DietaryVegan,
+	DietaryVegetarian,
+	DietaryHalal,
+	DietaryGlutenFree,
+}
+
+func IsValidAllergen(allergen string) bool {
+	return contains(Allergens, allergen)
+}
+
+func IsValidDietaryFlag(flag string) bool {
+	return contains(DietaryFlags, flag)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
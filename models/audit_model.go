@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	AuditActionCreate  = "CREATE"
+	AuditActionUpdate  = "UPDATE"
+	AuditActionDelete  = "DELETE"
+	AuditActionRestore = "RESTORE"
+)
+
+type AuditChange struct {
+	Field  string      `json:"field" bson:"field"`
+	Before interface{} `json:"before" bson:"before"`
+	After  interface{} `json:"after" bson:"after"`
+}
+
+type AuditLog struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id"`
+	Action       string             `json:"action" bson:"action"`
+	ResourceType string             `json:"resourceType" bson:"resourceType"`
+	ResourceID   string             `json:"resourceId" bson:"resourceId"`
+	UserID       *string            `json:"userId" bson:"userId"`
+	UserEmail    *string            `json:"userEmail" bson:"userEmail"`
+	ClientIP     string             `json:"clientIp" bson:"clientIp"`
+	Changes      []AuditChange      `json:"changes" bson:"changes"`
+	Timestamp    time.Time          `json:"timestamp" bson:"timestamp"`
+	AuditLogID   string             `json:"auditLogId" bson:"auditLogId"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	BundleComponentFixed  = "FIXED"
+	BundleComponentChoice = "CHOICE"
+)
+
+type BundleComponent struct {
+	ComponentID string   `json:"componentId" bson:"componentId"`
+	Name        string   `json:"name" bson:"name" validate:"required,min=2,max=100"`
+	Type        string   `json:"type" bson:"type" validate:"required,eq=FIXED|eq=CHOICE"`
+	FoodIDs     []string `json:"foodIds" bson:"foodIds" validate:"required,min=1,dive,required"`
+}
+
+type Bundle struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	Name        *string            `json:"name" bson:"name" validate:"required,min=2,max=100"`
+	Description *string            `json:"description" bson:"description"`
+	Price       *float64           `json:"price" bson:"price" validate:"required,gte=0"`
+	Components  []BundleComponent  `json:"components" bson:"components" validate:"required,min=1,dive"`
+	MenuID      *string            `json:"menuId" bson:"menuId"`
+	Active      *bool              `json:"active" bson:"active"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt" bson:"updatedAt"`
+	Version     int64              `json:"version" bson:"version"`
+	DeletedAt   *time.Time         `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	DeletedBy   *string            `json:"deletedBy,omitempty" bson:"deletedBy,omitempty"`
+	BundleID    string             `json:"bundleId" bson:"bundleId"`
+}
@@ -6,13 +6,30 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+type Nutrition struct {
+	Calories      *float64 `json:"calories" bson:"calories" validate:"omitempty,gte=0"`
+	Protein       *float64 `json:"protein" bson:"protein" validate:"omitempty,gte=0"`
+	Carbohydrates *float64 `json:"carbohydrates" bson:"carbohydrates" validate:"omitempty,gte=0"`
+	Fat           *float64 `json:"fat" bson:"fat" validate:"omitempty,gte=0"`
+	Sugar         *float64 `json:"sugar" bson:"sugar" validate:"omitempty,gte=0"`
+	Salt          *float64 `json:"salt" bson:"salt" validate:"omitempty,gte=0"`
+}
+
 type Food struct {
-	ID        primitive.ObjectID `json:"id" bson:"_id"`
-	Name      *string            `json:"name" validate:"required,min=2,max=100"`
-	Price     *float64           `json:"price" validate:"required"`
-	FoodImage *string            `json:"foodImage" bson:"foodImage" validate:"required"`
-	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
-	UpdatedAt time.Time          `json:"updatedAt" bson:"updatedAt"`
-	FoodID    string             `json:"foodId" bson:"foodId"`
-	MenuID    *string            `json:"menuId" bson:"menuId" validate:"required"`
+	ID           primitive.ObjectID `json:"id" bson:"_id"`
+	Name         *string            `json:"name" validate:"required,min=2,max=100"`
+	Description  *string            `json:"description" bson:"description" validate:"omitempty,max=1000"`
+	Price        *float64           `json:"price" validate:"required"`
+	FoodImage    *string            `json:"foodImage" bson:"foodImage"`
+	Thumbnails   map[string]string  `json:"thumbnails" bson:"thumbnails"`
+	Allergens    []string           `json:"allergens" bson:"allergens" validate:"omitempty,dive,allergen"`
+	DietaryFlags []string           `json:"dietaryFlags" bson:"dietaryFlags" validate:"omitempty,dive,dietaryflag"`
+	Nutrition    *Nutrition         `json:"nutrition" bson:"nutrition"`
+	CreatedAt    time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time          `json:"updatedAt" bson:"updatedAt"`
+	Version      int64              `json:"version" bson:"version"`
+	DeletedAt    *time.Time         `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	DeletedBy    *string            `json:"deletedBy,omitempty" bson:"deletedBy,omitempty"`
+	FoodID       string             `json:"foodId" bson:"foodId"`
+	MenuID       *string            `json:"menuId" bson:"menuId" validate:"required"`
 }
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FoodPrice struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id"`
+	Price         *float64           `json:"price" bson:"price" validate:"required,gte=0"`
+	EffectiveFrom *time.Time         `json:"effectiveFrom" bson:"effectiveFrom"`
+	Applied       bool               `json:"applied" bson:"applied"`
+	CreatedAt     time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt     time.Time          `json:"updatedAt" bson:"updatedAt"`
+	Version       int64              `json:"version" bson:"version"`
+	FoodPriceID   string             `json:"foodPriceId" bson:"foodPriceId"`
+	FoodID        string             `json:"foodId" bson:"foodId"`
+}
@@ -7,12 +7,19 @@ import (
 )
 
 type Invoice struct {
-	ID             primitive.ObjectID `json:"id" bson:"_id"`
-	InvoiceID      string             `json:"invoiceId" bson:"invoiceId"`
-	OrderID        string             `json:"orderId" bson:"orderId" validate:"required"`
-	PaymentMethod  *string            `json:"paymentMethod" bson:"paymentMethod" validate:"eq=CARD|eq=CASH|eq=ONLINE"`
-	PaymentStatus  *string            `json:"paymentStatus" bson:"paymentStatus" validate:"required,eq=PENDING|eq=PAID"`
-	PaymentDueDate time.Time          `json:"paymentDueDate" bson:"paymentDueDate" validate:"required"`
-	CreatedAt      time.Time          `json:"createdAt" bson:"createdAt"`
-	UpdatedAt      time.Time          `json:"updatedAt" bson:"updatedAt"`
+	ID                primitive.ObjectID `json:"id" bson:"_id"`
+	InvoiceID         string             `json:"invoiceId" bson:"invoiceId"`
+	OrderID           string             `json:"orderId" bson:"orderId" validate:"required"`
+	PaymentMethod     *string            `json:"paymentMethod" bson:"paymentMethod" validate:"eq=CARD|eq=CASH|eq=ONLINE"`
+	PaymentStatus     *string            `json:"paymentStatus" bson:"paymentStatus" validate:"required,eq=PENDING|eq=PAID"`
+	PaymentDueDate    time.Time          `json:"paymentDueDate" bson:"paymentDueDate" validate:"required"`
+	Subtotal          float64            `json:"subtotal" bson:"subtotal"`
+	DiscountTotal     float64            `json:"discountTotal" bson:"discountTotal"`
+	Total             float64            `json:"total" bson:"total"`
+	AppliedPromotions []AppliedPromotion `json:"appliedPromotions" bson:"appliedPromotions"`
+	CreatedAt         time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt         time.Time          `json:"updatedAt" bson:"updatedAt"`
+	Version           int64              `json:"version" bson:"version"`
+	DeletedAt         *time.Time         `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	DeletedBy         *string            `json:"deletedBy,omitempty" bson:"deletedBy,omitempty"`
 }
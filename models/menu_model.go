@@ -14,5 +14,8 @@ type Menu struct {
 	EndDate   *time.Time         `json:"endDate" bson:"endDate"`
 	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
 	UpdatedAt time.Time          `json:"updatedAt" bson:"updatedAt"`
+	Version   int64              `json:"version" bson:"version"`
+	DeletedAt *time.Time         `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	DeletedBy *string            `json:"deletedBy,omitempty" bson:"deletedBy,omitempty"`
 	MenuID    string             `json:"menuId" bson:"menuId"`
 }
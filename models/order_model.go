@@ -11,6 +11,9 @@ type Order struct {
 	OrderDate time.Time          `json:"orderDate" bson:"orderDate" validate:"required"`
 	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
 	UpdatedAt time.Time          `json:"updatedAt" bson:"updatedAt"`
+	Version   int64              `json:"version" bson:"version"`
+	DeletedAt *time.Time         `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	DeletedBy *string            `json:"deletedBy,omitempty" bson:"deletedBy,omitempty"`
 	OrderID   string             `json:"orderId" bson:"orderId"`
 	TableID   *string            `json:"tableId" bson:"tableId" validate:"required"`
 }
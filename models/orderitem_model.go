@@ -6,13 +6,27 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+type OrderItemComponent struct {
+	ComponentID string   `json:"componentId" bson:"componentId"`
+	Name        string   `json:"name" bson:"name"`
+	FoodID      string   `json:"foodId" bson:"foodId"`
+	FoodName    string   `json:"foodName" bson:"foodName"`
+	Allergens   []string `json:"allergens" bson:"allergens"`
+}
+
 type OrderItem struct {
-	ID          primitive.ObjectID `json:"id" bson:"_id"`
-	Quantity    *string            `json:"quantity" bson:"quantity" validate:"required,eq=S|eq=M|eq=L"`
-	UnitPrice   *float64           `json:"unitPrice" bson:"unitPrice" validate:"required"`
-	CreatedAt   time.Time          `json:"createdAt" bson:"createdAt"`
-	UpdatedAt   time.Time          `json:"updatedAt" bson:"updatedAt"`
-	FoodID      *string            `json:"foodId" bson:"foodId" validate:"required"`
-	OrderItemID string             `json:"orderItemId" bson:"orderItemId"`
-	OrderID     string             `json:"orderId" bson:"orderId" validate:"required"`
+	ID          primitive.ObjectID   `json:"id" bson:"_id"`
+	Quantity    *string              `json:"quantity" bson:"quantity" validate:"required,eq=S|eq=M|eq=L"`
+	UnitPrice   *float64             `json:"unitPrice" bson:"unitPrice" validate:"required"`
+	CreatedAt   time.Time            `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time            `json:"updatedAt" bson:"updatedAt"`
+	Version     int64                `json:"version" bson:"version"`
+	DeletedAt   *time.Time           `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	DeletedBy   *string              `json:"deletedBy,omitempty" bson:"deletedBy,omitempty"`
+	FoodID      *string              `json:"foodId" bson:"foodId" validate:"required_without=BundleID"`
+	BundleID    *string              `json:"bundleId" bson:"bundleId,omitempty"`
+	Components  []OrderItemComponent `json:"components" bson:"components,omitempty"`
+	Allergens   []string             `json:"allergens" bson:"allergens"`
+	OrderItemID string               `json:"orderItemId" bson:"orderItemId"`
+	OrderID     string               `json:"orderId" bson:"orderId" validate:"required"`
 }
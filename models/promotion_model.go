@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	PromotionTypePercentage = "PERCENTAGE"
+	PromotionTypeFixed      = "FIXED"
+	PromotionTypeBuyXGetY   = "BUY_X_GET_Y"
+)
+
+type Promotion struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id"`
+	Name          *string            `json:"name" bson:"name" validate:"required,min=2,max=100"`
+	Description   *string            `json:"description" bson:"description"`
+	Type          *string            `json:"type" bson:"type" validate:"required,eq=PERCENTAGE|eq=FIXED|eq=BUY_X_GET_Y"`
+	DiscountValue *float64           `json:"discountValue" bson:"discountValue" validate:"required_if=Type PERCENTAGE,required_if=Type FIXED,omitempty,gt=0"`
+	BuyQuantity   *int               `json:"buyQuantity" bson:"buyQuantity" validate:"required_if=Type BUY_X_GET_Y,omitempty,gte=1"`
+	GetQuantity   *int               `json:"getQuantity" bson:"getQuantity" validate:"required_if=Type BUY_X_GET_Y,omitempty,gte=1"`
+	FoodIDs       []string           `json:"foodIds" bson:"foodIds"`
+	MenuIDs       []string           `json:"menuIds" bson:"menuIds"`
+	Categories    []string           `json:"categories" bson:"categories"`
+	MinimumSpend  *float64           `json:"minimumSpend" bson:"minimumSpend" validate:"omitempty,gte=0"`
+	StartDate     *time.Time         `json:"startDate" bson:"startDate"`
+	EndDate       *time.Time         `json:"endDate" bson:"endDate"`
+	DaysOfWeek    []int              `json:"daysOfWeek" bson:"daysOfWeek" validate:"omitempty,dive,gte=0,lte=6"`
+	StartTime     *string            `json:"startTime" bson:"startTime" validate:"omitempty,datetime=15:04"`
+	EndTime       *string            `json:"endTime" bson:"endTime" validate:"omitempty,datetime=15:04"`
+	Active        *bool              `json:"active" bson:"active"`
+	CreatedAt     time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt     time.Time          `json:"updatedAt" bson:"updatedAt"`
+	Version       int64              `json:"version" bson:"version"`
+	DeletedAt     *time.Time         `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	DeletedBy     *string            `json:"deletedBy,omitempty" bson:"deletedBy,omitempty"`
+	PromotionID   string             `json:"promotionId" bson:"promotionId"`
+}
+
+type AppliedPromotion struct {
+	PromotionID  string   `json:"promotionId" bson:"promotionId"`
+	Name         string   `json:"name" bson:"name"`
+	Type         string   `json:"type" bson:"type"`
+	Discount     float64  `json:"discount" bson:"discount"`
+	OrderItemIDs []string `json:"orderItemIds" bson:"orderItemIds"`
+}
@@ -12,5 +12,8 @@ type Table struct {
 	TableNumber    *int               `json:"tableNumber" bson:"tableNumber" validate:"required"`
 	CreatedAt      time.Time          `json:"createdAt" bson:"createdAt"`
 	UpdatedAt      time.Time          `json:"updatedAt" bson:"updatedAt"`
+	Version        int64              `json:"version" bson:"version"`
+	DeletedAt      *time.Time         `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	DeletedBy      *string            `json:"deletedBy,omitempty" bson:"deletedBy,omitempty"`
 	TableID        string             `json:"tableId" bson:"tableId"`
 }
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+type SignUpRequest struct {
+	FirstName string  `json:"firstName" validate:"required,min=2,max=100"`
+	LastName  string  `json:"lastName" validate:"required,min=2,max=100"`
+	Password  string  `json:"password" validate:"required,min=6"`
+	Email     string  `json:"email" validate:"required,email"`
+	Phone     string  `json:"phone" validate:"required"`
+	Avatar    *string `json:"avatar"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type UserResponse struct {
+	UserID        string     `json:"userId"`
+	FirstName     *string    `json:"firstName"`
+	LastName      *string    `json:"lastName"`
+	Email         *string    `json:"email"`
+	Phone         *string    `json:"phone"`
+	Avatar        *string    `json:"avatar"`
+	Role          *string    `json:"role"`
+	EmailVerified bool       `json:"emailVerified"`
+	Deactivated   bool       `json:"deactivated"`
+	DeactivatedAt *time.Time `json:"deactivatedAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+	Version       int64      `json:"version"`
+}
+
+type AuthResponse struct {
+	Message      string       `json:"message"`
+	AccessToken  string       `json:"accessToken"`
+	RefreshToken string       `json:"refreshToken"`
+	User         UserResponse `json:"user"`
+}
+
+func NewUserResponse(user User) UserResponse {
+	return UserResponse{
+		UserID:        user.UserID,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Email:         user.Email,
+		Phone:         user.Phone,
+		Avatar:        user.Avatar,
+		Role:          user.Role,
+		EmailVerified: user.EmailVerified,
+		Deactivated:   user.Deactivated,
+		DeactivatedAt: user.DeactivatedAt,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+		Version:       user.Version,
+	}
+}
+
+func NewUserResponses(users []User) []UserResponse {
+	responses := make([]UserResponse, len(users))
+	for i, user := range users {
+		responses[i] = NewUserResponse(user)
+	}
+	return responses
+}
@@ -6,17 +6,32 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	UserRoleAdmin   = "ADMIN"
+	UserRoleManager = "MANAGER"
+	UserRoleStaff   = "STAFF"
+)
+
 type User struct {
-	ID           primitive.ObjectID `json:"id" bson:"_id"`
-	FirstName    *string            `json:"firstName" bson:"firstName" validate:"required,min=2,max=100"`
-	LastName     *string            `json:"lastName" bson:"lastName" validate:"required,min=2,max=100"`
-	Password     *string            `json:"password" validate:"required,min=6"`
-	Email        *string            `json:"email" validate:"email,required"`
-	Avatar       *string            `json:"avatar"`
-	Phone        *string            `json:"phone" validate:"required"`
-	AccessToken  *string            `json:"accessToken" bson:"accessToken"`
-	RefreshToken *string            `json:"refreshToken" bson:"refreshToken"`
-	CreatedAt    time.Time          `json:"createdAt" bson:"createdAt"`
-	UpdatedAt    time.Time          `json:"updatedAt" bson:"updatedAt"`
-	UserID       string             `json:"userId" bson:"userId"`
+	ID               primitive.ObjectID `json:"id" bson:"_id"`
+	FirstName        *string            `json:"firstName" bson:"firstName"`
+	LastName         *string            `json:"lastName" bson:"lastName"`
+	Password         *string            `json:"-" bson:"password"`
+	Email            *string            `json:"email" bson:"email"`
+	Avatar           *string            `json:"avatar" bson:"avatar"`
+	Phone            *string            `json:"phone" bson:"phone"`
+	Role             *string            `json:"role" bson:"role" validate:"omitempty,eq=ADMIN|eq=MANAGER|eq=STAFF"`
+	AccessTokenHash  *string            `json:"-" bson:"accessTokenHash,omitempty"`
+	RefreshTokenHash *string            `json:"-" bson:"refreshTokenHash,omitempty"`
+	EmailVerified    bool               `json:"emailVerified" bson:"emailVerified"`
+	EmailVerifiedAt  *time.Time         `json:"emailVerifiedAt,omitempty" bson:"emailVerifiedAt,omitempty"`
+	FailedLogins     int                `json:"-" bson:"failedLogins"`
+	Deactivated      bool               `json:"deactivated" bson:"deactivated"`
+	DeactivatedAt    *time.Time         `json:"deactivatedAt,omitempty" bson:"deactivatedAt,omitempty"`
+	TokensRevokedAt  *time.Time         `json:"-" bson:"tokensRevokedAt,omitempty"`
+	LockedUntil      *time.Time         `json:"-" bson:"lockedUntil,omitempty"`
+	CreatedAt        time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt        time.Time          `json:"updatedAt" bson:"updatedAt"`
+	Version          int64              `json:"version" bson:"version"`
+	UserID           string             `json:"userId" bson:"userId"`
 }
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	UserTokenPasswordReset     = "PASSWORD_RESET"
+	UserTokenEmailVerification = "EMAIL_VERIFICATION"
+)
+
+type UserToken struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	TokenHash   string             `json:"-" bson:"tokenHash"`
+	UserID      string             `json:"userId" bson:"userId"`
+	Purpose     string             `json:"purpose" bson:"purpose"`
+	ExpiresAt   time.Time          `json:"expiresAt" bson:"expiresAt"`
+	UsedAt      *time.Time         `json:"usedAt" bson:"usedAt"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"createdAt"`
+	UserTokenID string             `json:"userTokenId" bson:"userTokenId"`
+}
@@ -0,0 +1,86 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/ratelimit"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func bucketResponse(tokens float64, allowed bool) bson.D {
+	return mtest.CreateSuccessResponse(bson.E{Key: "value", Value: bson.D{
+		{Key: "_id", Value: "login:client"},
+		{Key: "tokens", Value: tokens},
+		{Key: "allowed", Value: allowed},
+	}})
+}
+
+func TestMongoStore(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	rule := ratelimit.PerMinute(60, 3)
+	now := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+
+	mt.Run("upserts the bucket atomically", func(mt *mtest.T) {
+		mt.AddMockResponses(bucketResponse(1.5, true))
+
+		decision, err := ratelimit.NewMongoStore(mt.Coll).Take(context.Background(), "login:client", rule, now)
+		if err != nil {
+			mt.Fatal(err)
+		}
+		if !decision.Allowed || decision.Remaining != 1 || decision.Limit != 3 {
+			mt.Fatalf("unexpected decision %+v", decision)
+		}
+
+		command := mt.GetStartedEvent().Command
+		if name := command.Index(0).Key(); name != "findAndModify" {
+			mt.Fatalf("expected a findAndModify, got %s", name)
+		}
+		if upsert, ok := command.Lookup("upsert").BooleanOK(); !ok || !upsert {
+			mt.Fatalf("expected an upsert: %s", command)
+		}
+		if id := command.Lookup("query", "_id").StringValue(); id != "login:client" {
+			mt.Fatalf("expected the bucket key in the query, got %q", id)
+		}
+		if _, ok := command.Lookup("update").ArrayOK(); !ok {
+			mt.Fatalf("expected an aggregation pipeline update: %s", command)
+		}
+	})
+
+	mt.Run("denies an empty bucket", func(mt *mtest.T) {
+		mt.AddMockResponses(bucketResponse(0.25, false))
+
+		decision, err := ratelimit.NewMongoStore(mt.Coll).Take(context.Background(), "login:client", rule, now)
+		if err != nil {
+			mt.Fatal(err)
+		}
+		if decision.Allowed || decision.Remaining != 0 || decision.RetryAfter != 750*time.Millisecond {
+			mt.Fatalf("unexpected decision %+v", decision)
+		}
+	})
+
+	mt.Run("retries a concurrent upsert", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 11000, Name: "DuplicateKey", Message: "E11000 duplicate key error"}),
+			bucketResponse(2, true),
+		)
+
+		decision, err := ratelimit.NewMongoStore(mt.Coll).Take(context.Background(), "login:client", rule, now)
+		if err != nil {
+			mt.Fatalf("expected the retry to succeed, got %v", err)
+		}
+		if !decision.Allowed || decision.Remaining != 2 {
+			mt.Fatalf("unexpected decision %+v", decision)
+		}
+	})
+
+	mt.Run("returns other errors", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 2, Name: "BadValue", Message: "bad value"}))
+
+		if _, err := ratelimit.NewMongoStore(mt.Coll).Take(context.Background(), "login:client", rule, now); err == nil {
+			mt.Fatal("expected the command error to be returned")
+		}
+	})
+}
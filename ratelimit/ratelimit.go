@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/config"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const Collection = "rate_limits"
+
+const (
+	StoreMemory = "memory"
+	StoreMongo  = "mongo"
+)
+
+type Rule struct {
+	Rate  float64
+	Burst int
+}
+
+func PerMinute(requests float64, burst int) Rule {
+	return Rule{Rate: requests / 60, Burst: burst}
+}
+
+func (r Rule) idleTTL() time.Duration {
+	return time.Duration(float64(r.Burst)/r.Rate*float64(time.Second)) + time.Minute
+}
+
+type Decision struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+type Store interface {
+	Take(ctx context.Context, key string, rule Rule, now time.Time) (Decision, error)
+}
+
+func NewStore(db *mongo.Database, cfg config.RateLimitConfig) Store {
+	if cfg.Store == StoreMongo {
+		return NewMongoStore(db.Collection(Collection))
+	}
+	return NewMemoryStore()
+}
+
+type Limiter struct {
+	name  string
+	rule  Rule
+	store Store
+}
+
+func NewLimiter(store Store, name string, rule Rule) *Limiter {
+	return &Limiter{name: name, rule: rule, store: store}
+}
+
+func (l *Limiter) Name() string {
+	return l.name
+}
+
+func (l *Limiter) Allow(ctx context.Context, key string) (Decision, error) {
+	return l.store.Take(ctx, l.name+":"+key, l.rule, time.Now().UTC())
+}
+
+func decide(tokens float64, allowed bool, rule Rule) Decision {
+	decision := Decision{Allowed: allowed, Limit: rule.Burst, Remaining: int(math.Floor(tokens))}
+	if !allowed {
+		decision.RetryAfter = time.Duration((1 - tokens) / rule.Rate * float64(time.Second))
+	}
+	return decision
+}
+
+func refill(tokens float64, last, now time.Time, rule Rule) float64 {
+	if elapsed := now.Sub(last).Seconds(); elapsed > 0 {
+		tokens += elapsed * rule.Rate
+	}
+	return math.Min(tokens, float64(rule.Burst))
+}
@@ -0,0 +1,100 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/ratelimit"
+)
+
+func TestMemoryStoreRefillsTheBucket(t *testing.T) {
+	ctx := context.Background()
+	store := ratelimit.NewMemoryStore()
+	rule := ratelimit.PerMinute(60, 3)
+	start := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		name       string
+		after      time.Duration
+		allowed    bool
+		remaining  int
+		retryAfter time.Duration
+	}{
+		{"first request uses the burst", 0, true, 2, 0},
+		{"second request", 0, true, 1, 0},
+		{"third request empties the bucket", 0, true, 0, 0},
+		{"empty bucket is denied", 0, false, 0, time.Second},
+		{"half a token is still denied", 500 * time.Millisecond, false, 0, 500 * time.Millisecond},
+		{"a full token is allowed", time.Second, true, 0, 0},
+		{"refill is capped at the burst", time.Hour, true, 2, 0},
+	}
+
+	for _, step := range steps {
+		decision, err := store.Take(ctx, "client", rule, start.Add(step.after))
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if decision.Allowed != step.allowed || decision.Remaining != step.remaining || decision.RetryAfter != step.retryAfter {
+			t.Fatalf("%s: got %+v", step.name, decision)
+		}
+		if decision.Limit != 3 {
+			t.Fatalf("%s: expected a limit of 3, got %d", step.name, decision.Limit)
+		}
+	}
+}
+
+func TestMemoryStoreKeepsKeysApart(t *testing.T) {
+	ctx := context.Background()
+	store := ratelimit.NewMemoryStore()
+	rule := ratelimit.PerMinute(1, 1)
+	now := time.Now().UTC()
+
+	if decision, _ := store.Take(ctx, "first", rule, now); !decision.Allowed {
+		t.Fatal("expected the first key to be allowed")
+	}
+	if decision, _ := store.Take(ctx, "first", rule, now); decision.Allowed {
+		t.Fatal("expected the first key to be limited")
+	}
+	if decision, _ := store.Take(ctx, "second", rule, now); !decision.Allowed {
+		t.Fatal("expected the second key to have its own bucket")
+	}
+}
+
+func TestMemoryStoreSweepsIdleBuckets(t *testing.T) {
+	ctx := context.Background()
+	store := ratelimit.NewMemoryStore()
+	rule := ratelimit.PerMinute(60, 3)
+	now := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+
+	if _, err := store.Take(ctx, "client", rule, now); err != nil {
+		t.Fatal(err)
+	}
+
+	// A bucket expires once it has had time to refill completely plus a minute.
+	if removed := store.Sweep(now.Add(63 * time.Second)); removed != 0 {
+		t.Fatalf("expected the bucket to be kept until it expires, removed %d", removed)
+	}
+	if removed := store.Sweep(now.Add(64 * time.Second)); removed != 1 {
+		t.Fatalf("expected the idle bucket to be removed, removed %d", removed)
+	}
+}
+
+func TestLimiterPrefixesKeysWithItsName(t *testing.T) {
+	ctx := context.Background()
+	store := ratelimit.NewMemoryStore()
+	rule := ratelimit.PerMinute(1, 1)
+
+	login := ratelimit.NewLimiter(store, "login", rule)
+	signup := ratelimit.NewLimiter(store, "signup", rule)
+
+	if decision, _ := login.Allow(ctx, "127.0.0.1"); !decision.Allowed {
+		t.Fatal("expected the first login to be allowed")
+	}
+	if decision, _ := login.Allow(ctx, "127.0.0.1"); decision.Allowed {
+		t.Fatal("expected the second login to be limited")
+	}
+	if decision, _ := signup.Allow(ctx, "127.0.0.1"); !decision.Allowed {
+		t.Fatal("expected signup to use its own bucket")
+	}
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/audit"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type auditTrail struct {
+	resourceType string
+	idField      string
+	logs         AuditRepository
+}
+
+func (t *auditTrail) record(ctx context.Context, before, after interface{}) {
+	if t == nil {
+		return
+	}
+
+	if err := t.write(ctx, before, after); err != nil {
+		utils.GetLogger().Error("Failed to write audit log",
+			zap.String("resourceType", t.resourceType),
+			zap.Error(err))
+	}
+}
+
+func (t *auditTrail) write(ctx context.Context, before, after interface{}) error {
+	beforeDocument, err := audit.ToDocument(before)
+	if err != nil {
+		return err
+	}
+	afterDocument, err := audit.ToDocument(after)
+	if err != nil {
+		return err
+	}
+
+	changes := audit.Diff(beforeDocument, afterDocument)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	entry := models.AuditLog{
+		ID:           primitive.NewObjectID(),
+		Action:       audit.Action(beforeDocument, afterDocument),
+		ResourceType: t.resourceType,
+		ResourceID:   fmt.Sprint(afterDocument[t.idField]),
+		Changes:      changes,
+		Timestamp:    time.Now().UTC(),
+	}
+	entry.AuditLogID = entry.ID.Hex()
+
+	if actor, ok := audit.ActorFrom(ctx); ok {
+		if actor.UserID != "" {
+			entry.UserID = &actor.UserID
+		}
+		if actor.Email != "" {
+			entry.UserEmail = &actor.Email
+		}
+		entry.ClientIP = actor.ClientIP
+	}
+
+	return t.logs.Create(context.WithoutCancel(ctx), &entry)
+}
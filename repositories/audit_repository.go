@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type AuditFilter struct {
+	UserID       string
+	ResourceType string
+	ResourceID   string
+	From         *time.Time
+	To           *time.Time
+}
+
+type AuditRepository interface {
+	Create(ctx context.Context, entry *models.AuditLog) error
+	List(ctx context.Context, filter AuditFilter, query Query) ([]models.AuditLog, int64, error)
+}
+
+type MongoAuditRepository struct {
+	logs *mongoCollection[models.AuditLog]
+}
+
+func NewMongoAuditRepository(collection *mongo.Collection) *MongoAuditRepository {
+	return &MongoAuditRepository{logs: &mongoCollection[models.AuditLog]{collection: collection, idField: "auditLogId"}}
+}
+
+func (r *MongoAuditRepository) Create(ctx context.Context, entry *models.AuditLog) error {
+	return r.logs.insert(ctx, entry)
+}
+
+func (r *MongoAuditRepository) List(ctx context.Context, filter AuditFilter, query Query) ([]models.AuditLog, int64, error) {
+	base := bson.M{}
+	if filter.UserID != "" {
+		base["userId"] = filter.UserID
+	}
+	if filter.ResourceType != "" {
+		base["resourceType"] = filter.ResourceType
+	}
+	if filter.ResourceID != "" {
+		base["resourceId"] = filter.ResourceID
+	}
+	if filter.From != nil || filter.To != nil {
+		timestamp := bson.M{}
+		if filter.From != nil {
+			timestamp["$gte"] = *filter.From
+		}
+		if filter.To != nil {
+			timestamp["$lte"] = *filter.To
+		}
+		base["timestamp"] = timestamp
+	}
+
+	return r.logs.query(ctx, base, newestFirst(query))
+}
+
+type MemoryAuditRepository struct {
+	logs *memoryCollection[models.AuditLog]
+}
+
+func NewMemoryAuditRepository() *MemoryAuditRepository {
+	return &MemoryAuditRepository{logs: newMemoryCollection(func(a *models.AuditLog) string { return a.AuditLogID })}
+}
+
+func (r *MemoryAuditRepository) Create(ctx context.Context, entry *models.AuditLog) error {
+	return r.logs.insert(ctx, entry)
+}
+
+func (r *MemoryAuditRepository) List(ctx context.Context, filter AuditFilter, query Query) ([]models.AuditLog, int64, error) {
+	return r.logs.query(ctx, func(a *models.AuditLog) bool {
+		if filter.UserID != "" && (a.UserID == nil || *a.UserID != filter.UserID) {
+			return false
+		}
+		if filter.ResourceType != "" && a.ResourceType != filter.ResourceType {
+			return false
+		}
+		if filter.ResourceID != "" && a.ResourceID != filter.ResourceID {
+			return false
+		}
+		if filter.From != nil && a.Timestamp.Before(*filter.From) {
+			return false
+		}
+		if filter.To != nil && a.Timestamp.After(*filter.To) {
+			return false
+		}
+		return true
+	}, newestFirst(query))
+}
+
+func newestFirst(query Query) Query {
+	if len(query.Sort) == 0 {
+		query.Sort = []SortField{{Field: "timestamp", Descending: true}}
+	}
+	return query
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type BundleRepository interface {
+	Create(ctx context.Context, bundle *models.Bundle) error
+	FindByID(ctx context.Context, bundleID string) (*models.Bundle, error)
+	List(ctx context.Context, query Query) ([]models.Bundle, int64, error)
+	ListByMenu(ctx context.Context, menuID string) ([]models.Bundle, error)
+	ListByFood(ctx context.Context, foodID string) ([]models.Bundle, error)
+	Update(ctx context.Context, bundle *models.Bundle) error
+}
+
+type MongoBundleRepository struct {
+	bundles *mongoCollection[models.Bundle]
+}
+
+func NewMongoBundleRepository(collection *mongo.Collection) *MongoBundleRepository {
+	return &MongoBundleRepository{bundles: &mongoCollection[models.Bundle]{collection: collection, idField: "bundleId", softDelete: true}}
+}
+
+func (r *MongoBundleRepository) Create(ctx context.Context, bundle *models.Bundle) error {
+	return r.bundles.insert(ctx, bundle)
+}
+
+func (r *MongoBundleRepository) FindByID(ctx context.Context, bundleID string) (*models.Bundle, error) {
+	return r.bundles.findByID(ctx, bundleID)
+}
+
+func (r *MongoBundleRepository) List(ctx context.Context, query Query) ([]models.Bundle, int64, error) {
+	return r.bundles.query(ctx, bson.M{}, query)
+}
+
+func (r *MongoBundleRepository) ListByMenu(ctx context.Context, menuID string) ([]models.Bundle, error) {
+	return r.bundles.find(ctx, bson.M{"menuId": menuID})
+}
+
+func (r *MongoBundleRepository) ListByFood(ctx context.Context, foodID string) ([]models.Bundle, error) {
+	return r.bundles.find(ctx, bson.M{"components.foodIds": foodID})
+}
+
+func (r *MongoBundleRepository) Update(ctx context.Context, bundle *models.Bundle) error {
+	return r.bundles.replace(ctx, bundle.BundleID, bundle)
+}
+
+type MemoryBundleRepository struct {
+	bundles *memoryCollection[models.Bundle]
+}
+
+func NewMemoryBundleRepository() *MemoryBundleRepository {
+	return &MemoryBundleRepository{bundles: newMemoryCollection(func(b *models.Bundle) string { return b.BundleID }).withSoftDelete()}
+}
+
+func (r *MemoryBundleRepository) Create(ctx context.Context, bundle *models.Bundle) error {
+	return r.bundles.insert(ctx, bundle)
+}
+
+func (r *MemoryBundleRepository) FindByID(ctx context.Context, bundleID string) (*models.Bundle, error) {
+	return r.bundles.get(ctx, bundleID)
+}
+
+func (r *MemoryBundleRepository) List(ctx context.Context, query Query) ([]models.Bundle, int64, error) {
+	return r.bundles.query(ctx, nil, query)
+}
+
+func (r *MemoryBundleRepository) ListByMenu(ctx context.Context, menuID string) ([]models.Bundle, error) {
+	return r.bundles.filter(ctx, func(b *models.Bundle) bool { return b.MenuID != nil && *b.MenuID == menuID })
+}
+
+func (r *MemoryBundleRepository) ListByFood(ctx context.Context, foodID string) ([]models.Bundle, error) {
+	return r.bundles.filter(ctx, func(b *models.Bundle) bool {
+		for _, component := range b.Components {
+			if containsString(component.FoodIDs, foodID) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (r *MemoryBundleRepository) Update(ctx context.Context, bundle *models.Bundle) error {
+	return r.bundles.replace(ctx, bundle.BundleID, bundle)
+}
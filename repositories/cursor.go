@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Cursor struct {
+	CreatedAt time.Time
+	ID        primitive.ObjectID
+	Backward  bool
+}
+
+type CursorPage struct {
+	Next *Cursor
+	Prev *Cursor
+}
+
+var keysetSort = []SortField{{Field: "createdAt", Descending: true}, {Field: "_id", Descending: true}}
+
+func (m *mongoCollection[T]) scroll(ctx context.Context, base bson.M, q Query, cursor *Cursor) ([]T, CursorPage, error) {
+	filter := q.mongoFilter(base)
+	if cursor != nil {
+		comparison := "$lt"
+		if cursor.Backward {
+			comparison = "$gt"
+		}
+		filter = bson.M{"$and": bson.A{filter, bson.M{"$or": bson.A{
+			bson.M{"createdAt": bson.M{comparison: cursor.CreatedAt}},
+			bson.M{"createdAt": cursor.CreatedAt, "_id": bson.M{comparison: cursor.ID}},
+		}}}}
+	}
+
+	direction := -1
+	if cursor != nil && cursor.Backward {
+		direction = 1
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: direction}, {Key: "_id", Value: direction}}).
+		SetLimit(q.Limit + 1)
+
+	docs, err := m.find(ctx, filter, opts)
+	if err != nil {
+		return nil, CursorPage{}, err
+	}
+
+	hasMore := int64(len(docs)) > q.Limit
+	if hasMore {
+		docs = docs[:q.Limit]
+	}
+	if cursor != nil && cursor.Backward {
+		for i, j := 0, len(docs)-1; i < j; i, j = i+1, j-1 {
+			docs[i], docs[j] = docs[j], docs[i]
+		}
+	}
+
+	return docs, cursorPage(docs, cursor, hasMore), nil
+}
+
+func (m *memoryCollection[T]) scroll(ctx context.Context, match func(*T) bool, q Query, cursor *Cursor) ([]T, CursorPage, error) {
+	all, _, err := m.query(ctx, match, Query{Conditions: q.Conditions, Sort: keysetSort})
+	if err != nil {
+		return nil, CursorPage{}, err
+	}
+
+	var docs []T
+	for _, doc := range all {
+		key, _ := cursorOf(&doc)
+		if cursor == nil || (cursor.Backward && afterCursor(key, *cursor)) || (!cursor.Backward && afterCursor(*cursor, key)) {
+			docs = append(docs, doc)
+		}
+	}
+
+	hasMore := int64(len(docs)) > q.Limit
+	if hasMore {
+		if cursor != nil && cursor.Backward {
+			docs = docs[int64(len(docs))-q.Limit:]
+		} else {
+			docs = docs[:q.Limit]
+		}
+	}
+	if docs == nil {
+		docs = []T{}
+	}
+
+	return docs, cursorPage(docs, cursor, hasMore), nil
+}
+
+func afterCursor(a, b Cursor) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID.Hex() > b.ID.Hex()
+}
+
+func cursorPage[T any](docs []T, cursor *Cursor, hasMore bool) CursorPage {
+	var page CursorPage
+	if len(docs) == 0 {
+		if cursor != nil {
+			reversed := *cursor
+			reversed.Backward = !cursor.Backward
+			if cursor.Backward {
+				page.Next = &reversed
+			} else {
+				page.Prev = &reversed
+			}
+		}
+		return page
+	}
+
+	first, _ := cursorOf(&docs[0])
+	last, _ := cursorOf(&docs[len(docs)-1])
+	first.Backward = true
+
+	if cursor != nil && cursor.Backward {
+		page.Next = &last
+		if hasMore {
+			page.Prev = &first
+		}
+	} else {
+		if hasMore {
+			page.Next = &last
+		}
+		if cursor != nil {
+			page.Prev = &first
+		}
+	}
+	return page
+}
+
+func cursorOf(doc interface{}) (Cursor, bool) {
+	value := reflect.ValueOf(doc)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return Cursor{}, false
+	}
+
+	createdAtField := value.Elem().FieldByName("CreatedAt")
+	idField := value.Elem().FieldByName("ID")
+	if !createdAtField.IsValid() || !idField.IsValid() {
+		return Cursor{}, false
+	}
+
+	createdAt, ok := createdAtField.Interface().(time.Time)
+	if !ok {
+		return Cursor{}, false
+	}
+	id, ok := idField.Interface().(primitive.ObjectID)
+	if !ok {
+		return Cursor{}, false
+	}
+	return Cursor{CreatedAt: createdAt, ID: id}, true
+}
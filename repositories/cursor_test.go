@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type cursorDoc struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	CreatedAt time.Time          `bson:"createdAt"`
+	Name      string             `bson:"name"`
+}
+
+func cursorDocs(t *testing.T) (*memoryCollection[cursorDoc], []string) {
+	t.Helper()
+
+	collection := newMemoryCollection(func(doc *cursorDoc) string { return doc.ID.Hex() })
+	earlier := time.Date(2026, time.March, 4, 12, 0, 0, 0, time.UTC)
+	tied := earlier.Add(time.Minute)
+
+	// Inserted out of order; the three "tied" documents share a timestamp and
+	// must be ordered by _id.
+	docs := []cursorDoc{
+		{Name: "tied-b", CreatedAt: tied, ID: objectID(t, 2)},
+		{Name: "oldest", CreatedAt: earlier, ID: objectID(t, 9)},
+		{Name: "tied-c", CreatedAt: tied, ID: objectID(t, 3)},
+		{Name: "newest", CreatedAt: tied.Add(time.Minute), ID: objectID(t, 1)},
+		{Name: "tied-a", CreatedAt: tied, ID: objectID(t, 17)},
+	}
+	for i := range docs {
+		if err := collection.insert(context.Background(), &docs[i]); err != nil {
+			t.Fatalf("inserting %s: %v", docs[i].Name, err)
+		}
+	}
+	return collection, []string{"newest", "tied-a", "tied-c", "tied-b", "oldest"}
+}
+
+func objectID(t *testing.T, n int) primitive.ObjectID {
+	t.Helper()
+
+	id, err := primitive.ObjectIDFromHex(fmt.Sprintf("%024x", n))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func names(docs []cursorDoc) []string {
+	result := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		result = append(result, doc.Name)
+	}
+	return result
+}
+
+func TestScrollOrdersTiesByID(t *testing.T) {
+	collection, want := cursorDocs(t)
+	ctx := context.Background()
+
+	var pages [][]string
+	var cursor *Cursor
+	for i := 0; i < len(want); i++ {
+		docs, page, err := collection.scroll(ctx, nil, Query{Limit: 2}, cursor)
+		if err != nil {
+			t.Fatalf("scrolling: %v", err)
+		}
+		pages = append(pages, names(docs))
+		if page.Next == nil {
+			break
+		}
+		cursor = page.Next
+	}
+
+	got := fmt.Sprint(pages)
+	if expected := fmt.Sprint([][]string{want[0:2], want[2:4], want[4:5]}); got != expected {
+		t.Fatalf("expected pages %s, got %s", expected, got)
+	}
+}
+
+func TestScrollBackwardReturnsThePreviousPage(t *testing.T) {
+	collection, want := cursorDocs(t)
+	ctx := context.Background()
+
+	_, first, err := collection.scroll(ctx, nil, Query{Limit: 2}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Prev != nil {
+		t.Fatal("expected no previous page on the first page")
+	}
+
+	second, page, err := collection.scroll(ctx, nil, Query{Limit: 2}, first.Next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(names(second)) != fmt.Sprint(want[2:4]) || page.Prev == nil {
+		t.Fatalf("unexpected second page %v with %+v", names(second), page)
+	}
+
+	previous, page, err := collection.scroll(ctx, nil, Query{Limit: 2}, page.Prev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(names(previous)) != fmt.Sprint(want[0:2]) {
+		t.Fatalf("expected to return to %v, got %v", want[0:2], names(previous))
+	}
+	if page.Prev != nil || page.Next == nil {
+		t.Fatalf("expected only a next cursor back on the first page, got %+v", page)
+	}
+}
+
+func TestScrollPastTheEnd(t *testing.T) {
+	collection, _ := cursorDocs(t)
+	ctx := context.Background()
+
+	cursor := &Cursor{CreatedAt: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), ID: primitive.NilObjectID}
+	docs, page, err := collection.scroll(ctx, nil, Query{Limit: 2}, cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 0 || page.Next != nil || page.Prev == nil || !page.Prev.Backward {
+		t.Fatalf("expected an empty page that can step back, got %v with %+v", names(docs), page)
+	}
+}
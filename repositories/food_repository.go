@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type FoodFilter struct {
+	MenuID           string
+	ExcludeAllergens []string
+	DietaryFlags     []string
+}
+
+type FoodRepository interface {
+	Create(ctx context.Context, food *models.Food) error
+	FindByID(ctx context.Context, foodID string) (*models.Food, error)
+	FindByIDs(ctx context.Context, foodIDs []string) ([]models.Food, error)
+	List(ctx context.Context, filter FoodFilter, query Query) ([]models.Food, int64, error)
+	Update(ctx context.Context, food *models.Food) error
+}
+
+type MongoFoodRepository struct {
+	foods *mongoCollection[models.Food]
+}
+
+func NewMongoFoodRepository(collection *mongo.Collection) *MongoFoodRepository {
+	return &MongoFoodRepository{foods: &mongoCollection[models.Food]{collection: collection, idField: "foodId", softDelete: true}}
+}
+
+func (r *MongoFoodRepository) Create(ctx context.Context, food *models.Food) error {
+	return r.foods.insert(ctx, food)
+}
+
+func (r *MongoFoodRepository) FindByID(ctx context.Context, foodID string) (*models.Food, error) {
+	return r.foods.findByID(ctx, foodID)
+}
+
+func (r *MongoFoodRepository) FindByIDs(ctx context.Context, foodIDs []string) ([]models.Food, error) {
+	return r.foods.find(ctx, bson.M{"foodId": bson.M{"$in": foodIDs}})
+}
+
+func (r *MongoFoodRepository) List(ctx context.Context, filter FoodFilter, query Query) ([]models.Food, int64, error) {
+	base := bson.M{}
+	if filter.MenuID != "" {
+		base["menuId"] = filter.MenuID
+	}
+	if len(filter.ExcludeAllergens) > 0 {
+		base["allergens"] = bson.M{"$nin": filter.ExcludeAllergens}
+	}
+	if len(filter.DietaryFlags) > 0 {
+		base["dietaryFlags"] = bson.M{"$all": filter.DietaryFlags}
+	}
+
+	return r.foods.query(ctx, base, query)
+}
+
+func (r *MongoFoodRepository) Update(ctx context.Context, food *models.Food) error {
+	return r.foods.replace(ctx, food.FoodID, food)
+}
+
+type MemoryFoodRepository struct {
+	foods *memoryCollection[models.Food]
+}
+
+func NewMemoryFoodRepository() *MemoryFoodRepository {
+	return &MemoryFoodRepository{foods: newMemoryCollection(func(f *models.Food) string { return f.FoodID }).withSoftDelete()}
+}
+
+func (r *MemoryFoodRepository) Create(ctx context.Context, food *models.Food) error {
+	return r.foods.insert(ctx, food)
+}
+
+func (r *MemoryFoodRepository) FindByID(ctx context.Context, foodID string) (*models.Food, error) {
+	return r.foods.get(ctx, foodID)
+}
+
+func (r *MemoryFoodRepository) FindByIDs(ctx context.Context, foodIDs []string) ([]models.Food, error) {
+	return r.foods.filter(ctx, func(f *models.Food) bool { return containsString(foodIDs, f.FoodID) })
+}
+
+func (r *MemoryFoodRepository) List(ctx context.Context, filter FoodFilter, query Query) ([]models.Food, int64, error) {
+	return r.foods.query(ctx, func(f *models.Food) bool {
+		if filter.MenuID != "" && (f.MenuID == nil || *f.MenuID != filter.MenuID) {
+			return false
+		}
+		for _, allergen := range filter.ExcludeAllergens {
+			if containsString(f.Allergens, allergen) {
+				return false
+			}
+		}
+		for _, flag := range filter.DietaryFlags {
+			if !containsString(f.DietaryFlags, flag) {
+				return false
+			}
+		}
+		return true
+	}, query)
+}
+
+func (r *MemoryFoodRepository) Update(ctx context.Context, food *models.Food) error {
+	return r.foods.replace(ctx, food.FoodID, food)
+}
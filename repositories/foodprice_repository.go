@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FoodPriceRepository interface {
+	Create(ctx context.Context, foodPrice *models.FoodPrice) error
+	FindEffective(ctx context.Context, foodID string, at time.Time) (*models.FoodPrice, error)
+	ListByFood(ctx context.Context, foodID string) ([]models.FoodPrice, error)
+	ListDue(ctx context.Context, at time.Time) ([]models.FoodPrice, error)
+	MarkApplied(ctx context.Context, foodPriceID string) error
+}
+
+type MongoFoodPriceRepository struct {
+	prices *mongoCollection[models.FoodPrice]
+}
+
+func NewMongoFoodPriceRepository(collection *mongo.Collection) *MongoFoodPriceRepository {
+	return &MongoFoodPriceRepository{prices: &mongoCollection[models.FoodPrice]{collection: collection, idField: "foodPriceId"}}
+}
+
+func (r *MongoFoodPriceRepository) Create(ctx context.Context, foodPrice *models.FoodPrice) error {
+	return r.prices.insert(ctx, foodPrice)
+}
+
+func (r *MongoFoodPriceRepository) FindEffective(ctx context.Context, foodID string, at time.Time) (*models.FoodPrice, error) {
+	filter := bson.M{
+		"foodId":        foodID,
+		"effectiveFrom": bson.M{"$lte": at.UTC()},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "effectiveFrom", Value: -1}, {Key: "createdAt", Value: -1}})
+	return r.prices.findOne(ctx, filter, opts)
+}
+
+func (r *MongoFoodPriceRepository) ListByFood(ctx context.Context, foodID string) ([]models.FoodPrice, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "effectiveFrom", Value: 1}, {Key: "createdAt", Value: 1}})
+	return r.prices.find(ctx, bson.M{"foodId": foodID}, opts)
+}
+
+func (r *MongoFoodPriceRepository) ListDue(ctx context.Context, at time.Time) ([]models.FoodPrice, error) {
+	filter := bson.M{
+		"applied":       bson.M{"$ne": true},
+		"effectiveFrom": bson.M{"$lte": at.UTC()},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "effectiveFrom", Value: 1}, {Key: "createdAt", Value: 1}})
+	return r.prices.find(ctx, filter, opts)
+}
+
+func (r *MongoFoodPriceRepository) MarkApplied(ctx context.Context, foodPriceID string) error {
+	return r.prices.updateFields(ctx, foodPriceID, bson.D{{Key: "applied", Value: true}})
+}
+
+type MemoryFoodPriceRepository struct {
+	prices *memoryCollection[models.FoodPrice]
+}
+
+func NewMemoryFoodPriceRepository() *MemoryFoodPriceRepository {
+	return &MemoryFoodPriceRepository{prices: newMemoryCollection(func(p *models.FoodPrice) string { return p.FoodPriceID })}
+}
+
+func (r *MemoryFoodPriceRepository) Create(ctx context.Context, foodPrice *models.FoodPrice) error {
+	return r.prices.insert(ctx, foodPrice)
+}
+
+func (r *MemoryFoodPriceRepository) FindEffective(ctx context.Context, foodID string, at time.Time) (*models.FoodPrice, error) {
+	prices, err := r.ListByFood(ctx, foodID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(prices) - 1; i >= 0; i-- {
+		if prices[i].EffectiveFrom != nil && !prices[i].EffectiveFrom.After(at) {
+			return &prices[i], nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *MemoryFoodPriceRepository) ListByFood(ctx context.Context, foodID string) ([]models.FoodPrice, error) {
+	prices, err := r.prices.filter(ctx, func(p *models.FoodPrice) bool { return p.FoodID == foodID })
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(prices, func(i, j int) bool {
+		a, b := prices[i], prices[j]
+		if a.EffectiveFrom == nil || b.EffectiveFrom == nil {
+			return b.EffectiveFrom != nil
+		}
+		if !a.EffectiveFrom.Equal(*b.EffectiveFrom) {
+			return a.EffectiveFrom.Before(*b.EffectiveFrom)
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+	return prices, nil
+}
+
+func (r *MemoryFoodPriceRepository) ListDue(ctx context.Context, at time.Time) ([]models.FoodPrice, error) {
+	prices, err := r.prices.filter(ctx, func(p *models.FoodPrice) bool {
+		return !p.Applied && p.EffectiveFrom != nil && !p.EffectiveFrom.After(at)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(prices, func(i, j int) bool { return prices[i].EffectiveFrom.Before(*prices[j].EffectiveFrom) })
+	return prices, nil
+}
+
+func (r *MemoryFoodPriceRepository) MarkApplied(ctx context.Context, foodPriceID string) error {
+	return r.prices.update(ctx, foodPriceID, func(p *models.FoodPrice) { p.Applied = true })
+}
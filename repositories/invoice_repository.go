@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type InvoiceRepository interface {
+	Create(ctx context.Context, invoice *models.Invoice) error
+	FindByID(ctx context.Context, invoiceID string) (*models.Invoice, error)
+	List(ctx context.Context, query Query) ([]models.Invoice, int64, error)
+	Scroll(ctx context.Context, query Query, cursor *Cursor) ([]models.Invoice, CursorPage, error)
+	ListByOrder(ctx context.Context, orderID string) ([]models.Invoice, error)
+	Update(ctx context.Context, invoice *models.Invoice) error
+	CountOpenTables(ctx context.Context) (int64, error)
+}
+
+type MongoInvoiceRepository struct {
+	invoices *mongoCollection[models.Invoice]
+	orders   string
+}
+
+func NewMongoInvoiceRepository(collection *mongo.Collection) *MongoInvoiceRepository {
+	return &MongoInvoiceRepository{invoices: &mongoCollection[models.Invoice]{collection: collection, idField: "invoiceId", softDelete: true}}
+}
+
+func (r *MongoInvoiceRepository) Create(ctx context.Context, invoice *models.Invoice) error {
+	return r.invoices.insert(ctx, invoice)
+}
+
+func (r *MongoInvoiceRepository) FindByID(ctx context.Context, invoiceID string) (*models.Invoice, error) {
+	return r.invoices.findByID(ctx, invoiceID)
+}
+
+func (r *MongoInvoiceRepository) List(ctx context.Context, query Query) ([]models.Invoice, int64, error) {
+	return r.invoices.query(ctx, bson.M{}, query)
+}
+
+func (r *MongoInvoiceRepository) Scroll(ctx context.Context, query Query, cursor *Cursor) ([]models.Invoice, CursorPage, error) {
+	return r.invoices.scroll(ctx, bson.M{}, query, cursor)
+}
+
+func (r *MongoInvoiceRepository) ListByOrder(ctx context.Context, orderID string) ([]models.Invoice, error) {
+	return r.invoices.find(ctx, bson.M{"orderId": orderID})
+}
+
+func (r *MongoInvoiceRepository) Update(ctx context.Context, invoice *models.Invoice) error {
+	return r.invoices.replace(ctx, invoice.InvoiceID, invoice)
+}
+
+func (r *MongoInvoiceRepository) CountOpenTables(ctx context.Context) (int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: notDeleted(bson.M{"paymentStatus": "PENDING"})}},
+		{{Key: "$lookup", Value: bson.M{"from": r.orders, "localField": "orderId", "foreignField": "orderId", "as": "order"}}},
+		{{Key: "$unwind", Value: "$order"}},
+		{{Key: "$match", Value: bson.M{"order.deletedAt": nil, "order.tableId": bson.M{"$nin": bson.A{nil, ""}}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$order.tableId"}}},
+		{{Key: "$count", Value: "tables"}},
+	}
+
+	cursor, err := r.invoices.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+
+	var results []struct {
+		Tables int64 `bson:"tables"`
+	}
+	if err := cursor.All(ctx, &results); err != nil || len(results) == 0 {
+		return 0, err
+	}
+	return results[0].Tables, nil
+}
+
+type MemoryInvoiceRepository struct {
+	invoices *memoryCollection[models.Invoice]
+	orders   *memoryCollection[models.Order]
+}
+
+func NewMemoryInvoiceRepository() *MemoryInvoiceRepository {
+	return &MemoryInvoiceRepository{invoices: newMemoryCollection(func(i *models.Invoice) string { return i.InvoiceID }).withSoftDelete()}
+}
+
+func (r *MemoryInvoiceRepository) Create(ctx context.Context, invoice *models.Invoice) error {
+	return r.invoices.insert(ctx, invoice)
+}
+
+func (r *MemoryInvoiceRepository) FindByID(ctx context.Context, invoiceID string) (*models.Invoice, error) {
+	return r.invoices.get(ctx, invoiceID)
+}
+
+func (r *MemoryInvoiceRepository) List(ctx context.Context, query Query) ([]models.Invoice, int64, error) {
+	return r.invoices.query(ctx, nil, query)
+}
+
+func (r *MemoryInvoiceRepository) Scroll(ctx context.Context, query Query, cursor *Cursor) ([]models.Invoice, CursorPage, error) {
+	return r.invoices.scroll(ctx, nil, query, cursor)
+}
+
+func (r *MemoryInvoiceRepository) ListByOrder(ctx context.Context, orderID string) ([]models.Invoice, error) {
+	return r.invoices.filter(ctx, func(i *models.Invoice) bool { return i.OrderID == orderID })
+}
+
+func (r *MemoryInvoiceRepository) Update(ctx context.Context, invoice *models.Invoice) error {
+	return r.invoices.replace(ctx, invoice.InvoiceID, invoice)
+}
+
+func (r *MemoryInvoiceRepository) CountOpenTables(ctx context.Context) (int64, error) {
+	invoices, err := r.invoices.filter(ctx, func(i *models.Invoice) bool {
+		return i.PaymentStatus != nil && *i.PaymentStatus == "PENDING"
+	})
+	if err != nil || r.orders == nil {
+		return 0, err
+	}
+
+	tables := make(map[string]struct{})
+	for _, invoice := range invoices {
+		order, err := r.orders.get(ctx, invoice.OrderID)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		} else if err != nil {
+			return 0, err
+		}
+		if order.TableID != nil && *order.TableID != "" {
+			tables[*order.TableID] = struct{}{}
+		}
+	}
+	return int64(len(tables)), nil
+}
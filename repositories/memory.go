@@ -0,0 +1,197 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type memoryCollection[T any] struct {
+	mu         sync.RWMutex
+	ids        []string
+	docs       map[string][]byte
+	keyOf      func(*T) string
+	softDelete bool
+	audit      *auditTrail
+}
+
+func newMemoryCollection[T any](keyOf func(*T) string) *memoryCollection[T] {
+	return &memoryCollection[T]{
+		docs:  make(map[string][]byte),
+		keyOf: keyOf,
+	}
+}
+
+func (m *memoryCollection[T]) withSoftDelete() *memoryCollection[T] {
+	m.softDelete = true
+	return m
+}
+
+func (m *memoryCollection[T]) visible(ctx context.Context, data []byte) bool {
+	return !m.softDelete || IncludesDeleted(ctx) || !isDeletedDocument(data)
+}
+
+func (m *memoryCollection[T]) audited(resourceType, idField string, logs AuditRepository) *memoryCollection[T] {
+	m.audit = &auditTrail{resourceType: resourceType, idField: idField, logs: logs}
+	return m
+}
+
+func (m *memoryCollection[T]) insert(ctx context.Context, doc *T) error {
+	initVersion(doc)
+
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	id := m.keyOf(doc)
+	if _, exists := m.docs[id]; exists {
+		m.mu.Unlock()
+		return ErrDuplicate
+	}
+
+	m.ids = append(m.ids, id)
+	m.docs[id] = data
+	m.mu.Unlock()
+
+	m.audit.record(ctx, nil, doc)
+	return nil
+}
+
+func (m *memoryCollection[T]) get(ctx context.Context, id string) (*T, error) {
+	m.mu.RLock()
+	data, ok := m.docs[id]
+	m.mu.RUnlock()
+
+	if !ok || !m.visible(ctx, data) {
+		return nil, ErrNotFound
+	}
+	return decodeMemoryDocument[T](data)
+}
+
+func (m *memoryCollection[T]) replace(ctx context.Context, id string, doc *T) error {
+	m.mu.Lock()
+	previous, ok := m.docs[id]
+	if !ok {
+		m.mu.Unlock()
+		return ErrNotFound
+	}
+
+	before, err := decodeMemoryDocument[T](previous)
+	if err != nil {
+		m.mu.Unlock()
+		return err
+	}
+
+	expected, versioned := versionOf(doc)
+	if versioned {
+		if current, _ := versionOf(before); current != expected {
+			m.mu.Unlock()
+			return ErrVersionConflict
+		}
+		setVersion(doc, expected+1)
+	}
+
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		if versioned {
+			setVersion(doc, expected)
+		}
+		m.mu.Unlock()
+		return err
+	}
+
+	m.docs[id] = data
+	m.mu.Unlock()
+
+	m.audit.record(ctx, before, doc)
+	return nil
+}
+
+func (m *memoryCollection[T]) update(ctx context.Context, id string, mutate func(*T)) error {
+	m.mu.Lock()
+	data, ok := m.docs[id]
+	if !ok {
+		m.mu.Unlock()
+		return ErrNotFound
+	}
+
+	before, err := decodeMemoryDocument[T](data)
+	if err != nil {
+		m.mu.Unlock()
+		return err
+	}
+	doc, err := decodeMemoryDocument[T](data)
+	if err != nil {
+		m.mu.Unlock()
+		return err
+	}
+	mutate(doc)
+	if version, ok := versionOf(doc); ok {
+		setVersion(doc, version+1)
+	}
+
+	data, err = bson.Marshal(doc)
+	if err != nil {
+		m.mu.Unlock()
+		return err
+	}
+
+	m.docs[id] = data
+	m.mu.Unlock()
+
+	m.audit.record(ctx, before, doc)
+	return nil
+}
+
+func (m *memoryCollection[T]) filter(ctx context.Context, match func(*T) bool) ([]T, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	docs := []T{}
+	for _, id := range m.ids {
+		if !m.visible(ctx, m.docs[id]) {
+			continue
+		}
+		doc, err := decodeMemoryDocument[T](m.docs[id])
+		if err != nil {
+			return nil, err
+		}
+		if match == nil || match(doc) {
+			docs = append(docs, *doc)
+		}
+	}
+	return docs, nil
+}
+
+func paginate[T any](docs []T, skip, limit int64) []T {
+	total := int64(len(docs))
+	if skip >= total {
+		return []T{}
+	}
+
+	end := total
+	if limit > 0 && skip+limit < total {
+		end = skip + limit
+	}
+	return docs[skip:end]
+}
+
+func decodeMemoryDocument[T any](data []byte) (*T, error) {
+	var doc T
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MenuRepository interface {
+	Create(ctx context.Context, menu *models.Menu) error
+	FindByID(ctx context.Context, menuID string) (*models.Menu, error)
+	FindByIDs(ctx context.Context, menuIDs []string) ([]models.Menu, error)
+	List(ctx context.Context, query Query) ([]models.Menu, int64, error)
+	Update(ctx context.Context, menu *models.Menu) error
+}
+
+type MongoMenuRepository struct {
+	menus *mongoCollection[models.Menu]
+}
+
+func NewMongoMenuRepository(collection *mongo.Collection) *MongoMenuRepository {
+	return &MongoMenuRepository{menus: &mongoCollection[models.Menu]{collection: collection, idField: "menuId", softDelete: true}}
+}
+
+func (r *MongoMenuRepository) Create(ctx context.Context, menu *models.Menu) error {
+	return r.menus.insert(ctx, menu)
+}
+
+func (r *MongoMenuRepository) FindByID(ctx context.Context, menuID string) (*models.Menu, error) {
+	return r.menus.findByID(ctx, menuID)
+}
+
+func (r *MongoMenuRepository) FindByIDs(ctx context.Context, menuIDs []string) ([]models.Menu, error) {
+	return r.menus.find(ctx, bson.M{"menuId": bson.M{"$in": menuIDs}})
+}
+
+func (r *MongoMenuRepository) List(ctx context.Context, query Query) ([]models.Menu, int64, error) {
+	return r.menus.query(ctx, bson.M{}, query)
+}
+
+func (r *MongoMenuRepository) Update(ctx context.Context, menu *models.Menu) error {
+	return r.menus.replace(ctx, menu.MenuID, menu)
+}
+
+type MemoryMenuRepository struct {
+	menus *memoryCollection[models.Menu]
+}
+
+func NewMemoryMenuRepository() *MemoryMenuRepository {
+	return &MemoryMenuRepository{menus: newMemoryCollection(func(m *models.Menu) string { return m.MenuID }).withSoftDelete()}
+}
+
+func (r *MemoryMenuRepository) Create(ctx context.Context, menu *models.Menu) error {
+	return r.menus.insert(ctx, menu)
+}
+
+func (r *MemoryMenuRepository) FindByID(ctx context.Context, menuID string) (*models.Menu, error) {
+	return r.menus.get(ctx, menuID)
+}
+
+func (r *MemoryMenuRepository) FindByIDs(ctx context.Context, menuIDs []string) ([]models.Menu, error) {
+	return r.menus.filter(ctx, func(m *models.Menu) bool { return containsString(menuIDs, m.MenuID) })
+}
+
+func (r *MemoryMenuRepository) List(ctx context.Context, query Query) ([]models.Menu, int64, error) {
+	return r.menus.query(ctx, nil, query)
+}
+
+func (r *MemoryMenuRepository) Update(ctx context.Context, menu *models.Menu) error {
+	return r.menus.replace(ctx, menu.MenuID, menu)
+}
@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoCollection[T any] struct {
+	collection *mongo.Collection
+	idField    string
+	softDelete bool
+	audit      *auditTrail
+}
+
+func (m *mongoCollection[T]) audited(resourceType string, logs AuditRepository) {
+	m.audit = &auditTrail{resourceType: resourceType, idField: m.idField, logs: logs}
+}
+
+func (m *mongoCollection[T]) scope(ctx context.Context, filter interface{}) interface{} {
+	if m.softDelete && !IncludesDeleted(ctx) {
+		return notDeleted(filter)
+	}
+	return filter
+}
+
+func (m *mongoCollection[T]) insert(ctx context.Context, doc *T) error {
+	initVersion(doc)
+
+	_, err := m.collection.InsertOne(ctx, doc)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicate
+	} else if err != nil {
+		return err
+	}
+
+	m.audit.record(ctx, nil, doc)
+	return nil
+}
+
+func (m *mongoCollection[T]) insertMany(ctx context.Context, docs []T) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(docs))
+	for i := range docs {
+		initVersion(&docs[i])
+		documents = append(documents, docs[i])
+	}
+
+	_, err := m.collection.InsertMany(ctx, documents)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicate
+	} else if err != nil {
+		return err
+	}
+
+	for i := range docs {
+		m.audit.record(ctx, nil, &docs[i])
+	}
+	return nil
+}
+
+func (m *mongoCollection[T]) findOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) (*T, error) {
+	var doc T
+	err := m.collection.FindOne(ctx, m.scope(ctx, filter), opts...).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (m *mongoCollection[T]) findByID(ctx context.Context, id string) (*T, error) {
+	return m.findOne(ctx, bson.M{m.idField: id})
+}
+
+func (m *mongoCollection[T]) find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) ([]T, error) {
+	cursor, err := m.collection.Find(ctx, m.scope(ctx, filter), opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	docs := []T{}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+func (m *mongoCollection[T]) replace(ctx context.Context, id string, doc *T) error {
+	var before *T
+	if m.audit != nil {
+		var previous T
+		if err := m.collection.FindOne(ctx, bson.M{m.idField: id}).Decode(&previous); err == nil {
+			before = &previous
+		}
+	}
+
+	filter := bson.M{m.idField: id}
+	expected, versioned := versionOf(doc)
+	if versioned {
+		filter["version"] = expected
+		setVersion(doc, expected+1)
+	}
+
+	result, err := m.collection.ReplaceOne(ctx, filter, doc)
+	if err != nil {
+		if versioned {
+			setVersion(doc, expected)
+		}
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrDuplicate
+		}
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		if versioned {
+			setVersion(doc, expected)
+		}
+		return m.missingOrConflict(ctx, id, versioned)
+	}
+
+	m.audit.record(ctx, before, doc)
+	return nil
+}
+
+func (m *mongoCollection[T]) missingOrConflict(ctx context.Context, id string, versioned bool) error {
+	if !versioned {
+		return ErrNotFound
+	}
+
+	count, err := m.collection.CountDocuments(ctx, bson.M{m.idField: id})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return ErrVersionConflict
+}
+
+func (m *mongoCollection[T]) updateFields(ctx context.Context, id string, fields bson.D) error {
+	update := bson.D{{Key: "$set", Value: fields}}
+	var doc T
+	if _, versioned := versionOf(&doc); versioned {
+		update = append(update, bson.E{Key: "$inc", Value: bson.M{"version": 1}})
+	}
+
+	_, err := m.updateOne(ctx, id, update)
+	return err
+}
+
+func (m *mongoCollection[T]) updateOne(ctx context.Context, id string, update interface{}) (*T, error) {
+	var before *T
+	if m.audit != nil {
+		var previous T
+		if err := m.collection.FindOne(ctx, bson.M{m.idField: id}).Decode(&previous); err == nil {
+			before = &previous
+		}
+	}
+
+	var after T
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := m.collection.FindOneAndUpdate(ctx, bson.M{m.idField: id}, update, opts).Decode(&after)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	m.audit.record(ctx, before, &after)
+	return &after, nil
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type OrderRepository interface {
+	Create(ctx context.Context, order *models.Order) error
+	FindByID(ctx context.Context, orderID string) (*models.Order, error)
+	List(ctx context.Context, query Query) ([]models.Order, int64, error)
+	Scroll(ctx context.Context, query Query, cursor *Cursor) ([]models.Order, CursorPage, error)
+	Update(ctx context.Context, order *models.Order) error
+}
+
+type MongoOrderRepository struct {
+	orders *mongoCollection[models.Order]
+}
+
+func NewMongoOrderRepository(collection *mongo.Collection) *MongoOrderRepository {
+	return &MongoOrderRepository{orders: &mongoCollection[models.Order]{collection: collection, idField: "orderId", softDelete: true}}
+}
+
+func (r *MongoOrderRepository) Create(ctx context.Context, order *models.Order) error {
+	return r.orders.insert(ctx, order)
+}
+
+func (r *MongoOrderRepository) FindByID(ctx context.Context, orderID string) (*models.Order, error) {
+	return r.orders.findByID(ctx, orderID)
+}
+
+func (r *MongoOrderRepository) List(ctx context.Context, query Query) ([]models.Order, int64, error) {
+	return r.orders.query(ctx, bson.M{}, query)
+}
+
+func (r *MongoOrderRepository) Scroll(ctx context.Context, query Query, cursor *Cursor) ([]models.Order, CursorPage, error) {
+	return r.orders.scroll(ctx, bson.M{}, query, cursor)
+}
+
+func (r *MongoOrderRepository) Update(ctx context.Context, order *models.Order) error {
+	return r.orders.replace(ctx, order.OrderID, order)
+}
+
+type MemoryOrderRepository struct {
+	orders *memoryCollection[models.Order]
+}
+
+func NewMemoryOrderRepository() *MemoryOrderRepository {
+	return &MemoryOrderRepository{orders: newMemoryCollection(func(o *models.Order) string { return o.OrderID }).withSoftDelete()}
+}
+
+func (r *MemoryOrderRepository) Create(ctx context.Context, order *models.Order) error {
+	return r.orders.insert(ctx, order)
+}
+
+func (r *MemoryOrderRepository) FindByID(ctx context.Context, orderID string) (*models.Order, error) {
+	return r.orders.get(ctx, orderID)
+}
+
+func (r *MemoryOrderRepository) List(ctx context.Context, query Query) ([]models.Order, int64, error) {
+	return r.orders.query(ctx, nil, query)
+}
+
+func (r *MemoryOrderRepository) Scroll(ctx context.Context, query Query, cursor *Cursor) ([]models.Order, CursorPage, error) {
+	return r.orders.scroll(ctx, nil, query, cursor)
+}
+
+func (r *MemoryOrderRepository) Update(ctx context.Context, order *models.Order) error {
+	return r.orders.replace(ctx, order.OrderID, order)
+}
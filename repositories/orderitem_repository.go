@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type OrderItemRepository interface {
+	CreateMany(ctx context.Context, orderItems []models.OrderItem) error
+	FindByID(ctx context.Context, orderItemID string) (*models.OrderItem, error)
+	List(ctx context.Context, query Query) ([]models.OrderItem, int64, error)
+	Scroll(ctx context.Context, query Query, cursor *Cursor) ([]models.OrderItem, CursorPage, error)
+	ListByOrder(ctx context.Context, orderID string) ([]models.OrderItem, error)
+	Update(ctx context.Context, orderItem *models.OrderItem) error
+}
+
+type MongoOrderItemRepository struct {
+	orderItems *mongoCollection[models.OrderItem]
+}
+
+func NewMongoOrderItemRepository(collection *mongo.Collection) *MongoOrderItemRepository {
+	return &MongoOrderItemRepository{orderItems: &mongoCollection[models.OrderItem]{collection: collection, idField: "orderItemId", softDelete: true}}
+}
+
+func (r *MongoOrderItemRepository) CreateMany(ctx context.Context, orderItems []models.OrderItem) error {
+	return r.orderItems.insertMany(ctx, orderItems)
+}
+
+func (r *MongoOrderItemRepository) FindByID(ctx context.Context, orderItemID string) (*models.OrderItem, error) {
+	return r.orderItems.findByID(ctx, orderItemID)
+}
+
+func (r *MongoOrderItemRepository) List(ctx context.Context, query Query) ([]models.OrderItem, int64, error) {
+	return r.orderItems.query(ctx, bson.M{}, query)
+}
+
+func (r *MongoOrderItemRepository) Scroll(ctx context.Context, query Query, cursor *Cursor) ([]models.OrderItem, CursorPage, error) {
+	return r.orderItems.scroll(ctx, bson.M{}, query, cursor)
+}
+
+func (r *MongoOrderItemRepository) ListByOrder(ctx context.Context, orderID string) ([]models.OrderItem, error) {
+	return r.orderItems.find(ctx, bson.M{"orderId": orderID})
+}
+
+func (r *MongoOrderItemRepository) Update(ctx context.Context, orderItem *models.OrderItem) error {
+	return r.orderItems.replace(ctx, orderItem.OrderItemID, orderItem)
+}
+
+type MemoryOrderItemRepository struct {
+	orderItems *memoryCollection[models.OrderItem]
+}
+
+func NewMemoryOrderItemRepository() *MemoryOrderItemRepository {
+	return &MemoryOrderItemRepository{orderItems: newMemoryCollection(func(o *models.OrderItem) string { return o.OrderItemID }).withSoftDelete()}
+}
+
+func (r *MemoryOrderItemRepository) CreateMany(ctx context.Context, orderItems []models.OrderItem) error {
+	for i := range orderItems {
+		if err := r.orderItems.insert(ctx, &orderItems[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MemoryOrderItemRepository) FindByID(ctx context.Context, orderItemID string) (*models.OrderItem, error) {
+	return r.orderItems.get(ctx, orderItemID)
+}
+
+func (r *MemoryOrderItemRepository) List(ctx context.Context, query Query) ([]models.OrderItem, int64, error) {
+	return r.orderItems.query(ctx, nil, query)
+}
+
+func (r *MemoryOrderItemRepository) Scroll(ctx context.Context, query Query, cursor *Cursor) ([]models.OrderItem, CursorPage, error) {
+	return r.orderItems.scroll(ctx, nil, query, cursor)
+}
+
+func (r *MemoryOrderItemRepository) ListByOrder(ctx context.Context, orderID string) ([]models.OrderItem, error) {
+	return r.orderItems.filter(ctx, func(o *models.OrderItem) bool { return o.OrderID == orderID })
+}
+
+func (r *MemoryOrderItemRepository) Update(ctx context.Context, orderItem *models.OrderItem) error {
+	return r.orderItems.replace(ctx, orderItem.OrderItemID, orderItem)
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion *models.Promotion) error
+	FindByID(ctx context.Context, promotionID string) (*models.Promotion, error)
+	List(ctx context.Context, query Query) ([]models.Promotion, int64, error)
+	ListActive(ctx context.Context) ([]models.Promotion, error)
+	Update(ctx context.Context, promotion *models.Promotion) error
+}
+
+type MongoPromotionRepository struct {
+	promotions *mongoCollection[models.Promotion]
+}
+
+func NewMongoPromotionRepository(collection *mongo.Collection) *MongoPromotionRepository {
+	return &MongoPromotionRepository{promotions: &mongoCollection[models.Promotion]{collection: collection, idField: "promotionId", softDelete: true}}
+}
+
+func (r *MongoPromotionRepository) Create(ctx context.Context, promotion *models.Promotion) error {
+	return r.promotions.insert(ctx, promotion)
+}
+
+func (r *MongoPromotionRepository) FindByID(ctx context.Context, promotionID string) (*models.Promotion, error) {
+	return r.promotions.findByID(ctx, promotionID)
+}
+
+func (r *MongoPromotionRepository) List(ctx context.Context, query Query) ([]models.Promotion, int64, error) {
+	return r.promotions.query(ctx, bson.M{}, query)
+}
+
+func (r *MongoPromotionRepository) ListActive(ctx context.Context) ([]models.Promotion, error) {
+	return r.promotions.find(ctx, bson.M{"active": bson.M{"$ne": false}})
+}
+
+func (r *MongoPromotionRepository) Update(ctx context.Context, promotion *models.Promotion) error {
+	return r.promotions.replace(ctx, promotion.PromotionID, promotion)
+}
+
+type MemoryPromotionRepository struct {
+	promotions *memoryCollection[models.Promotion]
+}
+
+func NewMemoryPromotionRepository() *MemoryPromotionRepository {
+	return &MemoryPromotionRepository{promotions: newMemoryCollection(func(p *models.Promotion) string { return p.PromotionID }).withSoftDelete()}
+}
+
+func (r *MemoryPromotionRepository) Create(ctx context.Context, promotion *models.Promotion) error {
+	return r.promotions.insert(ctx, promotion)
+}
+
+func (r *MemoryPromotionRepository) FindByID(ctx context.Context, promotionID string) (*models.Promotion, error) {
+	return r.promotions.get(ctx, promotionID)
+}
+
+func (r *MemoryPromotionRepository) List(ctx context.Context, query Query) ([]models.Promotion, int64, error) {
+	return r.promotions.query(ctx, nil, query)
+}
+
+func (r *MemoryPromotionRepository) ListActive(ctx context.Context) ([]models.Promotion, error) {
+	return r.promotions.filter(ctx, func(p *models.Promotion) bool { return p.Active == nil || *p.Active })
+}
+
+func (r *MemoryPromotionRepository) Update(ctx context.Context, promotion *models.Promotion) error {
+	return r.promotions.replace(ctx, promotion.PromotionID, promotion)
+}
@@ -0,0 +1,296 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type Operator string
+
+const (
+	OpEq  Operator = "eq"
+	OpNe  Operator = "ne"
+	OpGt  Operator = "gt"
+	OpGte Operator = "gte"
+	OpLt  Operator = "lt"
+	OpLte Operator = "lte"
+	OpIn  Operator = "in"
+)
+
+type Condition struct {
+	Field    string
+	Operator Operator
+	Value    interface{}
+}
+
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+type Query struct {
+	Conditions []Condition
+	Sort       []SortField
+	Skip       int64
+	Limit      int64
+}
+
+func (q Query) Where(field string, operator Operator, value interface{}) Query {
+	q.Conditions = append(append([]Condition{}, q.Conditions...), Condition{Field: field, Operator: operator, Value: value})
+	return q
+}
+
+func (q Query) mongoFilter(base bson.M) bson.M {
+	if len(q.Conditions) == 0 {
+		if base == nil {
+			return bson.M{}
+		}
+		return base
+	}
+
+	clauses := bson.A{}
+	if len(base) > 0 {
+		clauses = append(clauses, base)
+	}
+	for _, condition := range q.Conditions {
+		clauses = append(clauses, bson.M{condition.Field: bson.M{"$" + string(condition.Operator): condition.Value}})
+	}
+	return bson.M{"$and": clauses}
+}
+
+func (q Query) mongoOptions() *options.FindOptions {
+	opts := options.Find().SetSkip(q.Skip)
+	if q.Limit > 0 {
+		opts.SetLimit(q.Limit)
+	}
+
+	if len(q.Sort) > 0 {
+		sortSpec := bson.D{}
+		for _, field := range q.Sort {
+			sortSpec = append(sortSpec, bson.E{Key: field.Field, Value: sortDirection(field.Descending)})
+		}
+		last := q.Sort[len(q.Sort)-1]
+		if last.Field != "_id" {
+			sortSpec = append(sortSpec, bson.E{Key: "_id", Value: sortDirection(last.Descending)})
+		}
+		opts.SetSort(sortSpec)
+	}
+	return opts
+}
+
+func sortDirection(descending bool) int {
+	if descending {
+		return -1
+	}
+	return 1
+}
+
+func (m *mongoCollection[T]) query(ctx context.Context, base bson.M, q Query) ([]T, int64, error) {
+	filter := q.mongoFilter(base)
+
+	docs, err := m.find(ctx, filter, q.mongoOptions())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalCount, err := m.collection.CountDocuments(ctx, m.scope(ctx, filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return docs, totalCount, nil
+}
+
+func (m *memoryCollection[T]) query(ctx context.Context, match func(*T) bool, q Query) ([]T, int64, error) {
+	m.mu.RLock()
+	var raws []bson.Raw
+	for _, id := range m.ids {
+		data := m.docs[id]
+		if m.visible(ctx, data) && matchesConditions(data, q.Conditions) {
+			raws = append(raws, data)
+		}
+	}
+	m.mu.RUnlock()
+
+	sortRaw(raws, q.Sort)
+
+	docs := []T{}
+	for _, data := range raws {
+		doc, err := decodeMemoryDocument[T](data)
+		if err != nil {
+			return nil, 0, err
+		}
+		if match == nil || match(doc) {
+			docs = append(docs, *doc)
+		}
+	}
+	return paginate(docs, q.Skip, q.Limit), int64(len(docs)), nil
+}
+
+func matchesConditions(data bson.Raw, conditions []Condition) bool {
+	for _, condition := range conditions {
+		if !matchesCondition(data.Lookup(strings.Split(condition.Field, ".")...), condition) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesCondition(field bson.RawValue, condition Condition) bool {
+	if condition.Operator == OpIn {
+		values, _ := condition.Value.([]interface{})
+		for _, value := range values {
+			if compared, ok := compareRawValue(field, value); ok && compared == 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	compared, ok := compareRawValue(field, condition.Value)
+	if !ok {
+		return condition.Operator == OpNe
+	}
+
+	switch condition.Operator {
+	case OpEq:
+		return compared == 0
+	case OpNe:
+		return compared != 0
+	case OpGt:
+		return compared > 0
+	case OpGte:
+		return compared >= 0
+	case OpLt:
+		return compared < 0
+	case OpLte:
+		return compared <= 0
+	}
+	return false
+}
+
+func compareRawValue(field bson.RawValue, value interface{}) (int, bool) {
+	if field.Type == bsontype.Array {
+		values, err := field.Array().Values()
+		if err != nil {
+			return 0, false
+		}
+		for _, element := range values {
+			if compared, ok := compareRawValue(element, value); ok && compared == 0 {
+				return 0, true
+			}
+		}
+		return 0, false
+	}
+
+	current, ok := rawScalar(field)
+	if !ok {
+		return 0, false
+	}
+	return compareScalars(current, value)
+}
+
+func rawScalar(value bson.RawValue) (interface{}, bool) {
+	switch value.Type {
+	case bsontype.String:
+		return value.StringValue(), true
+	case bsontype.Double:
+		return value.Double(), true
+	case bsontype.Int32:
+		return float64(value.Int32()), true
+	case bsontype.Int64:
+		return float64(value.Int64()), true
+	case bsontype.Boolean:
+		return value.Boolean(), true
+	case bsontype.DateTime:
+		return value.Time().UTC(), true
+	case bsontype.ObjectID:
+		return value.ObjectID().Hex(), true
+	}
+	return nil, false
+}
+
+func compareScalars(a, b interface{}) (int, bool) {
+	switch left := a.(type) {
+	case string:
+		right, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(left, right), true
+	case float64:
+		var right float64
+		switch value := b.(type) {
+		case float64:
+			right = value
+		case int:
+			right = float64(value)
+		case int64:
+			right = float64(value)
+		default:
+			return 0, false
+		}
+		switch {
+		case left < right:
+			return -1, true
+		case left > right:
+			return 1, true
+		}
+		return 0, true
+	case bool:
+		right, ok := b.(bool)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case left == right:
+			return 0, true
+		case !left:
+			return -1, true
+		}
+		return 1, true
+	case time.Time:
+		right, ok := b.(time.Time)
+		if !ok {
+			return 0, false
+		}
+		return left.Compare(right), true
+	}
+	return 0, false
+}
+
+func sortRaw(raws []bson.Raw, fields []SortField) {
+	if len(fields) == 0 {
+		return
+	}
+
+	sort.SliceStable(raws, func(i, j int) bool {
+		for _, field := range fields {
+			left, leftOK := rawScalar(raws[i].Lookup(strings.Split(field.Field, ".")...))
+			right, rightOK := rawScalar(raws[j].Lookup(strings.Split(field.Field, ".")...))
+
+			if leftOK != rightOK {
+				return leftOK == field.Descending
+			}
+			if !leftOK {
+				continue
+			}
+
+			compared, ok := compareScalars(left, right)
+			if !ok || compared == 0 {
+				continue
+			}
+			if field.Descending {
+				return compared > 0
+			}
+			return compared < 0
+		}
+		return false
+	})
+}
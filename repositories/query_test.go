@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMongoFilterKeepsTheBaseScope(t *testing.T) {
+	query := Query{}.
+		Where("menuId", OpEq, "B").
+		Where("price", OpGte, 1.0).
+		Where("price", OpGte, 5.0)
+
+	got := query.mongoFilter(bson.M{"menuId": "A"})
+	want := bson.M{"$and": bson.A{
+		bson.M{"menuId": "A"},
+		bson.M{"menuId": bson.M{"$eq": "B"}},
+		bson.M{"price": bson.M{"$gte": 1.0}},
+		bson.M{"price": bson.M{"$gte": 5.0}},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("mongoFilter() = %v, want %v", got, want)
+	}
+}
+
+func TestMongoFilterWithoutConditions(t *testing.T) {
+	base := bson.M{"menuId": "A"}
+	if got := (Query{}).mongoFilter(base); !reflect.DeepEqual(got, base) {
+		t.Fatalf("mongoFilter() = %v, want %v", got, base)
+	}
+	if got := (Query{}).mongoFilter(nil); len(got) != 0 {
+		t.Fatalf("mongoFilter(nil) = %v, want an empty filter", got)
+	}
+}
@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrNotFound        = errors.New("record not found")
+	ErrDuplicate       = errors.New("duplicate record")
+	ErrVersionConflict = errors.New("record was modified by another request")
+)
+
+type Repositories struct {
+	Users      UserRepository
+	Menus      MenuRepository
+	Foods      FoodRepository
+	FoodPrices FoodPriceRepository
+	Tables     TableRepository
+	Orders     OrderRepository
+	OrderItems OrderItemRepository
+	Invoices   InvoiceRepository
+	Promotions PromotionRepository
+	Bundles    BundleRepository
+	AuditLogs  AuditRepository
+	UserTokens UserTokenRepository
+}
+
+func NewMongoRepositories(db *mongo.Database) *Repositories {
+	auditLogs := NewMongoAuditRepository(db.Collection("audit_log"))
+
+	users := NewMongoUserRepository(db.Collection("user"))
+	users.users.audited("user", auditLogs)
+	userTokens := NewMongoUserTokenRepository(db.Collection("userToken"))
+	menus := NewMongoMenuRepository(db.Collection("menu"))
+	menus.menus.audited("menu", auditLogs)
+	foods := NewMongoFoodRepository(db.Collection("food"))
+	foods.foods.audited("food", auditLogs)
+	foodPrices := NewMongoFoodPriceRepository(db.Collection("foodPrice"))
+	foodPrices.prices.audited("foodPrice", auditLogs)
+	tables := NewMongoTableRepository(db.Collection("table"))
+	tables.tables.audited("table", auditLogs)
+	orders := NewMongoOrderRepository(db.Collection("order"))
+	orders.orders.audited("order", auditLogs)
+	orderItems := NewMongoOrderItemRepository(db.Collection("orderItem"))
+	orderItems.orderItems.audited("orderItem", auditLogs)
+	invoices := NewMongoInvoiceRepository(db.Collection("invoice"))
+	invoices.invoices.audited("invoice", auditLogs)
+	invoices.orders = orders.orders.collection.Name()
+	promotions := NewMongoPromotionRepository(db.Collection("promotion"))
+	promotions.promotions.audited("promotion", auditLogs)
+	bundles := NewMongoBundleRepository(db.Collection("bundle"))
+	bundles.bundles.audited("bundle", auditLogs)
+
+	return &Repositories{
+		Users:      users,
+		Menus:      menus,
+		Foods:      foods,
+		FoodPrices: foodPrices,
+		Tables:     tables,
+		Orders:     orders,
+		OrderItems: orderItems,
+		Invoices:   invoices,
+		Promotions: promotions,
+		Bundles:    bundles,
+		AuditLogs:  auditLogs,
+		UserTokens: userTokens,
+	}
+}
+
+func NewMemoryRepositories() *Repositories {
+	auditLogs := NewMemoryAuditRepository()
+
+	users := NewMemoryUserRepository()
+	users.users.audited("user", "userId", auditLogs)
+	userTokens := NewMemoryUserTokenRepository()
+	menus := NewMemoryMenuRepository()
+	menus.menus.audited("menu", "menuId", auditLogs)
+	foods := NewMemoryFoodRepository()
+	foods.foods.audited("food", "foodId", auditLogs)
+	foodPrices := NewMemoryFoodPriceRepository()
+	foodPrices.prices.audited("foodPrice", "foodPriceId", auditLogs)
+	tables := NewMemoryTableRepository()
+	tables.tables.audited("table", "tableId", auditLogs)
+	orders := NewMemoryOrderRepository()
+	orders.orders.audited("order", "orderId", auditLogs)
+	orderItems := NewMemoryOrderItemRepository()
+	orderItems.orderItems.audited("orderItem", "orderItemId", auditLogs)
+	invoices := NewMemoryInvoiceRepository()
+	invoices.invoices.audited("invoice", "invoiceId", auditLogs)
+	invoices.orders = orders.orders
+	promotions := NewMemoryPromotionRepository()
+	promotions.promotions.audited("promotion", "promotionId", auditLogs)
+	bundles := NewMemoryBundleRepository()
+	bundles.bundles.audited("bundle", "bundleId", auditLogs)
+
+	return &Repositories{
+		Users:      users,
+		Menus:      menus,
+		Foods:      foods,
+		FoodPrices: foodPrices,
+		Tables:     tables,
+		Orders:     orders,
+		OrderItems: orderItems,
+		Invoices:   invoices,
+		Promotions: promotions,
+		Bundles:    bundles,
+		AuditLogs:  auditLogs,
+		UserTokens: userTokens,
+	}
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+type includeDeletedKey struct{}
+
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, includeDeletedKey{}, true)
+}
+
+func IncludesDeleted(ctx context.Context) bool {
+	include, _ := ctx.Value(includeDeletedKey{}).(bool)
+	return include
+}
+
+func notDeleted(filter interface{}) interface{} {
+	return bson.M{"$and": bson.A{filter, bson.M{"deletedAt": nil}}}
+}
+
+func isDeletedDocument(data bson.Raw) bool {
+	value, err := data.LookupErr("deletedAt")
+	return err == nil && value.Type != bsontype.Null
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TableRepository interface {
+	Create(ctx context.Context, table *models.Table) error
+	FindByID(ctx context.Context, tableID string) (*models.Table, error)
+	List(ctx context.Context, query Query) ([]models.Table, int64, error)
+	Update(ctx context.Context, table *models.Table) error
+}
+
+type MongoTableRepository struct {
+	tables *mongoCollection[models.Table]
+}
+
+func NewMongoTableRepository(collection *mongo.Collection) *MongoTableRepository {
+	return &MongoTableRepository{tables: &mongoCollection[models.Table]{collection: collection, idField: "tableId", softDelete: true}}
+}
+
+func (r *MongoTableRepository) Create(ctx context.Context, table *models.Table) error {
+	return r.tables.insert(ctx, table)
+}
+
+func (r *MongoTableRepository) FindByID(ctx context.Context, tableID string) (*models.Table, error) {
+	return r.tables.findByID(ctx, tableID)
+}
+
+func (r *MongoTableRepository) List(ctx context.Context, query Query) ([]models.Table, int64, error) {
+	return r.tables.query(ctx, bson.M{}, query)
+}
+
+func (r *MongoTableRepository) Update(ctx context.Context, table *models.Table) error {
+	return r.tables.replace(ctx, table.TableID, table)
+}
+
+type MemoryTableRepository struct {
+	tables *memoryCollection[models.Table]
+}
+
+func NewMemoryTableRepository() *MemoryTableRepository {
+	return &MemoryTableRepository{tables: newMemoryCollection(func(t *models.Table) string { return t.TableID }).withSoftDelete()}
+}
+
+func (r *MemoryTableRepository) Create(ctx context.Context, table *models.Table) error {
+	return r.tables.insert(ctx, table)
+}
+
+func (r *MemoryTableRepository) FindByID(ctx context.Context, tableID string) (*models.Table, error) {
+	return r.tables.get(ctx, tableID)
+}
+
+func (r *MemoryTableRepository) List(ctx context.Context, query Query) ([]models.Table, int64, error) {
+	return r.tables.query(ctx, nil, query)
+}
+
+func (r *MemoryTableRepository) Update(ctx context.Context, table *models.Table) error {
+	return r.tables.replace(ctx, table.TableID, table)
+}
@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type UserRepository interface {
+	Create(ctx context.Context, user *models.User) error
+	Update(ctx context.Context, user *models.User) error
+	FindByID(ctx context.Context, userID string) (*models.User, error)
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	ExistsByEmailOrPhone(ctx context.Context, email, phone string) (bool, error)
+	ExistsByPhone(ctx context.Context, phone, excludeUserID string) (bool, error)
+	List(ctx context.Context, query Query) ([]models.User, int64, error)
+	UpdateTokens(ctx context.Context, userID, accessTokenHash, refreshTokenHash string) error
+	RecordFailedLogin(ctx context.Context, userID string) (int, error)
+	LockUntil(ctx context.Context, userID string, until time.Time) error
+	ResetFailedLogins(ctx context.Context, userID string) error
+	MarkEmailVerified(ctx context.Context, userID string, at time.Time) error
+	UpdatePassword(ctx context.Context, userID, hashedPassword string) error
+}
+
+type MongoUserRepository struct {
+	users *mongoCollection[models.User]
+}
+
+func NewMongoUserRepository(collection *mongo.Collection) *MongoUserRepository {
+	return &MongoUserRepository{users: &mongoCollection[models.User]{collection: collection, idField: "userId"}}
+}
+
+func (r *MongoUserRepository) Create(ctx context.Context, user *models.User) error {
+	return r.users.insert(ctx, user)
+}
+
+func (r *MongoUserRepository) Update(ctx context.Context, user *models.User) error {
+	return r.users.replace(ctx, user.UserID, user)
+}
+
+func (r *MongoUserRepository) FindByID(ctx context.Context, userID string) (*models.User, error) {
+	return r.users.findByID(ctx, userID)
+}
+
+func (r *MongoUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	return r.users.findOne(ctx, bson.M{"email": email})
+}
+
+func (r *MongoUserRepository) ExistsByEmailOrPhone(ctx context.Context, email, phone string) (bool, error) {
+	filter := bson.M{"$or": bson.A{bson.M{"email": email}, bson.M{"phone": phone}}}
+	count, err := r.users.collection.CountDocuments(ctx, filter)
+	return count > 0, err
+}
+
+func (r *MongoUserRepository) ExistsByPhone(ctx context.Context, phone, excludeUserID string) (bool, error) {
+	filter := bson.M{"phone": phone, "userId": bson.M{"$ne": excludeUserID}}
+	count, err := r.users.collection.CountDocuments(ctx, filter)
+	return count > 0, err
+}
+
+func (r *MongoUserRepository) List(ctx context.Context, query Query) ([]models.User, int64, error) {
+	return r.users.query(ctx, bson.M{}, query)
+}
+
+func (r *MongoUserRepository) UpdateTokens(ctx context.Context, userID, accessTokenHash, refreshTokenHash string) error {
+	return r.users.updateFields(ctx, userID, bson.D{
+		{Key: "accessTokenHash", Value: accessTokenHash},
+		{Key: "refreshTokenHash", Value: refreshTokenHash},
+		{Key: "updatedAt", Value: time.Now().UTC()},
+	})
+}
+
+func (r *MongoUserRepository) RecordFailedLogin(ctx context.Context, userID string) (int, error) {
+	update := bson.M{
+		"$inc": bson.M{"failedLogins": 1, "version": 1},
+		"$set": bson.M{"updatedAt": time.Now().UTC()},
+	}
+	user, err := r.users.updateOne(ctx, userID, update)
+	if err != nil {
+		return 0, err
+	}
+	return user.FailedLogins, nil
+}
+
+func (r *MongoUserRepository) LockUntil(ctx context.Context, userID string, until time.Time) error {
+	return r.users.updateFields(ctx, userID, bson.D{
+		{Key: "lockedUntil", Value: until},
+		{Key: "updatedAt", Value: time.Now().UTC()},
+	})
+}
+
+func (r *MongoUserRepository) ResetFailedLogins(ctx context.Context, userID string) error {
+	_, err := r.users.updateOne(ctx, userID, bson.M{
+		"$set":   bson.M{"failedLogins": 0, "updatedAt": time.Now().UTC()},
+		"$unset": bson.M{"lockedUntil": ""},
+		"$inc":   bson.M{"version": 1},
+	})
+	return err
+}
+
+func (r *MongoUserRepository) MarkEmailVerified(ctx context.Context, userID string, at time.Time) error {
+	return r.users.updateFields(ctx, userID, bson.D{
+		{Key: "emailVerified", Value: true},
+		{Key: "emailVerifiedAt", Value: at},
+		{Key: "updatedAt", Value: time.Now().UTC()},
+	})
+}
+
+func (r *MongoUserRepository) UpdatePassword(ctx context.Context, userID, hashedPassword string) error {
+	now := time.Now().UTC()
+	_, err := r.users.updateOne(ctx, userID, bson.M{
+		"$set":   bson.M{"password": hashedPassword, "failedLogins": 0, "tokensRevokedAt": now, "updatedAt": now},
+		"$unset": bson.M{"accessTokenHash": "", "refreshTokenHash": "", "lockedUntil": ""},
+		"$inc":   bson.M{"version": 1},
+	})
+	return err
+}
+
+type MemoryUserRepository struct {
+	users *memoryCollection[models.User]
+}
+
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{users: newMemoryCollection(func(u *models.User) string { return u.UserID })}
+}
+
+func (r *MemoryUserRepository) Create(ctx context.Context, user *models.User) error {
+	return r.users.insert(ctx, user)
+}
+
+func (r *MemoryUserRepository) Update(ctx context.Context, user *models.User) error {
+	return r.users.replace(ctx, user.UserID, user)
+}
+
+func (r *MemoryUserRepository) FindByID(ctx context.Context, userID string) (*models.User, error) {
+	return r.users.get(ctx, userID)
+}
+
+func (r *MemoryUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	users, err := r.users.filter(ctx, func(u *models.User) bool {
+		return u.Email != nil && *u.Email == email
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, ErrNotFound
+	}
+	return &users[0], nil
+}
+
+func (r *MemoryUserRepository) ExistsByEmailOrPhone(ctx context.Context, email, phone string) (bool, error) {
+	users, err := r.users.filter(ctx, func(u *models.User) bool {
+		return (u.Email != nil && *u.Email == email) || (u.Phone != nil && *u.Phone == phone)
+	})
+	return len(users) > 0, err
+}
+
+func (r *MemoryUserRepository) ExistsByPhone(ctx context.Context, phone, excludeUserID string) (bool, error) {
+	users, err := r.users.filter(ctx, func(u *models.User) bool {
+		return u.UserID != excludeUserID && u.Phone != nil && *u.Phone == phone
+	})
+	return len(users) > 0, err
+}
+
+func (r *MemoryUserRepository) List(ctx context.Context, query Query) ([]models.User, int64, error) {
+	return r.users.query(ctx, nil, query)
+}
+
+func (r *MemoryUserRepository) UpdateTokens(ctx context.Context, userID, accessTokenHash, refreshTokenHash string) error {
+	return r.users.update(ctx, userID, func(u *models.User) {
+		u.AccessTokenHash = &accessTokenHash
+		u.RefreshTokenHash = &refreshTokenHash
+		u.UpdatedAt = time.Now().UTC()
+	})
+}
+
+func (r *MemoryUserRepository) RecordFailedLogin(ctx context.Context, userID string) (int, error) {
+	var attempts int
+	err := r.users.update(ctx, userID, func(u *models.User) {
+		u.FailedLogins++
+		u.UpdatedAt = time.Now().UTC()
+		attempts = u.FailedLogins
+	})
+	return attempts, err
+}
+
+func (r *MemoryUserRepository) LockUntil(ctx context.Context, userID string, until time.Time) error {
+	return r.users.update(ctx, userID, func(u *models.User) {
+		u.LockedUntil = &until
+		u.UpdatedAt = time.Now().UTC()
+	})
+}
+
+func (r *MemoryUserRepository) ResetFailedLogins(ctx context.Context, userID string) error {
+	return r.users.update(ctx, userID, func(u *models.User) {
+		u.FailedLogins = 0
+		u.LockedUntil = nil
+		u.UpdatedAt = time.Now().UTC()
+	})
+}
+
+func (r *MemoryUserRepository) MarkEmailVerified(ctx context.Context, userID string, at time.Time) error {
+	return r.users.update(ctx, userID, func(u *models.User) {
+		u.EmailVerified = true
+		u.EmailVerifiedAt = &at
+		u.UpdatedAt = time.Now().UTC()
+	})
+}
+
+func (r *MemoryUserRepository) UpdatePassword(ctx context.Context, userID, hashedPassword string) error {
+	now := time.Now().UTC()
+	return r.users.update(ctx, userID, func(u *models.User) {
+		u.Password = &hashedPassword
+		u.AccessTokenHash = nil
+		u.RefreshTokenHash = nil
+		u.FailedLogins = 0
+		u.LockedUntil = nil
+		u.TokensRevokedAt = &now
+		u.UpdatedAt = now
+	})
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *models.UserToken) error
+	Consume(ctx context.Context, tokenHash, purpose string, now time.Time) (*models.UserToken, error)
+	InvalidateForUser(ctx context.Context, userID, purpose string, now time.Time) error
+}
+
+type MongoUserTokenRepository struct {
+	tokens *mongoCollection[models.UserToken]
+}
+
+func NewMongoUserTokenRepository(collection *mongo.Collection) *MongoUserTokenRepository {
+	return &MongoUserTokenRepository{tokens: &mongoCollection[models.UserToken]{collection: collection, idField: "userTokenId"}}
+}
+
+func (r *MongoUserTokenRepository) Create(ctx context.Context, token *models.UserToken) error {
+	return r.tokens.insert(ctx, token)
+}
+
+func (r *MongoUserTokenRepository) Consume(ctx context.Context, tokenHash, purpose string, now time.Time) (*models.UserToken, error) {
+	filter := bson.M{
+		"tokenHash": tokenHash,
+		"purpose":   purpose,
+		"usedAt":    nil,
+		"expiresAt": bson.M{"$gt": now},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var token models.UserToken
+	err := r.tokens.collection.FindOneAndUpdate(ctx, filter, bson.M{"$set": bson.M{"usedAt": now}}, opts).Decode(&token)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *MongoUserTokenRepository) InvalidateForUser(ctx context.Context, userID, purpose string, now time.Time) error {
+	_, err := r.tokens.collection.UpdateMany(ctx,
+		bson.M{"userId": userID, "purpose": purpose, "usedAt": nil},
+		bson.M{"$set": bson.M{"usedAt": now}},
+	)
+	return err
+}
+
+type MemoryUserTokenRepository struct {
+	tokens *memoryCollection[models.UserToken]
+}
+
+func NewMemoryUserTokenRepository() *MemoryUserTokenRepository {
+	return &MemoryUserTokenRepository{tokens: newMemoryCollection(func(t *models.UserToken) string { return t.TokenHash })}
+}
+
+func (r *MemoryUserTokenRepository) Create(ctx context.Context, token *models.UserToken) error {
+	return r.tokens.insert(ctx, token)
+}
+
+func (r *MemoryUserTokenRepository) Consume(ctx context.Context, tokenHash, purpose string, now time.Time) (*models.UserToken, error) {
+	var consumed *models.UserToken
+	err := r.tokens.update(ctx, tokenHash, func(t *models.UserToken) {
+		if t.Purpose == purpose && t.UsedAt == nil && t.ExpiresAt.After(now) {
+			t.UsedAt = &now
+			token := *t
+			consumed = &token
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if consumed == nil {
+		return nil, ErrNotFound
+	}
+	return consumed, nil
+}
+
+func (r *MemoryUserTokenRepository) InvalidateForUser(ctx context.Context, userID, purpose string, now time.Time) error {
+	tokens, err := r.tokens.filter(ctx, func(t *models.UserToken) bool {
+		return t.UserID == userID && t.Purpose == purpose && t.UsedAt == nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		if err := r.tokens.update(ctx, token.TokenHash, func(t *models.UserToken) { t.UsedAt = &now }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import "reflect"
+
+func versionField(doc interface{}) (reflect.Value, bool) {
+	value := reflect.ValueOf(doc)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return reflect.Value{}, false
+	}
+
+	field := value.Elem().FieldByName("Version")
+	if !field.IsValid() || field.Kind() != reflect.Int64 {
+		return reflect.Value{}, false
+	}
+	return field, true
+}
+
+func versionOf(doc interface{}) (int64, bool) {
+	field, ok := versionField(doc)
+	if !ok {
+		return 0, false
+	}
+	return field.Int(), true
+}
+
+func setVersion(doc interface{}, version int64) {
+	if field, ok := versionField(doc); ok {
+		field.SetInt(version)
+	}
+}
+
+func initVersion(doc interface{}) {
+	if version, ok := versionOf(doc); ok && version == 0 {
+		setVersion(doc, 1)
+	}
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/datarohit/go-restaurant-management-backend-project/controllers"
+	"github.com/datarohit/go-restaurant-management-backend-project/middlewares"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AdminRoutes(router *gin.Engine, ctrl *controllers.LoggingController) {
+	api := router.Group("/api/v1")
+	{
+		admin := api.Group("/admin", middlewares.RequireRole(models.UserRoleAdmin))
+		{
+			admin.GET("/log-level", ctrl.GetLogLevel())
+			admin.PUT("/log-level", ctrl.UpdateLogLevel())
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/datarohit/go-restaurant-management-backend-project/controllers"
+	"github.com/datarohit/go-restaurant-management-backend-project/middlewares"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AuditRoutes(router *gin.Engine, ctrl *controllers.AuditController) {
+	api := router.Group("/api/v1")
+	{
+		audit := api.Group("/audit", middlewares.RequireRole(models.UserRoleAdmin))
+		{
+			audit.GET("/", ctrl.GetAuditLogs())
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/datarohit/go-restaurant-management-backend-project/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func BundleRoutes(router *gin.Engine, ctrl *controllers.BundleController) {
+	api := router.Group("/api/v1")
+	{
+		bundles := api.Group("/bundles")
+		{
+			bundles.POST("/", ctrl.CreateBundle())
+			bundles.GET("/", ctrl.GetAllBundles())
+			bundles.GET("/:bundleId", ctrl.GetBundleByID())
+			bundles.PATCH("/:bundleId", ctrl.UpdateBundleByID())
+			bundles.DELETE("/:bundleId", ctrl.DeleteBundleByID())
+			bundles.POST("/:bundleId/restore", ctrl.RestoreBundleByID())
+		}
+	}
+}
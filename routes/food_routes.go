@@ -6,15 +6,21 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func FoodRoutes(router *gin.Engine) {
+func FoodRoutes(router *gin.Engine, foodCtrl *controllers.FoodController, priceCtrl *controllers.FoodPriceController, imageCtrl *controllers.ImageController) {
 	api := router.Group("/api/v1")
 	{
 		foods := api.Group("/foods")
 		{
-			foods.POST("/", controllers.CreateFood())
-			foods.GET("/", controllers.GetAllFoodItems())
-			foods.GET("/:foodId", controllers.GetFoodByID())
-			foods.PATCH("/:foodId", controllers.UpdateFoodByID())
+			foods.POST("/", foodCtrl.CreateFood())
+			foods.GET("/", foodCtrl.GetAllFoodItems())
+			foods.GET("/menu/:menuId", foodCtrl.GetFoodsByMenuID())
+			foods.GET("/:foodId", foodCtrl.GetFoodByID())
+			foods.PATCH("/:foodId", foodCtrl.UpdateFoodByID())
+			foods.DELETE("/:foodId", foodCtrl.DeleteFoodByID())
+			foods.POST("/:foodId/restore", foodCtrl.RestoreFoodByID())
+			foods.POST("/:foodId/image", imageCtrl.UploadFoodImage())
+			foods.GET("/:foodId/prices", priceCtrl.GetFoodPrices())
+			foods.POST("/:foodId/prices", priceCtrl.CreateFoodPrice())
 		}
 	}
 }
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/datarohit/go-restaurant-management-backend-project/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func GuestRoutes(router *gin.Engine, foodCtrl *controllers.FoodController) {
+	api := router.Group("/api/v1")
+	{
+		guest := api.Group("/guest")
+		{
+			guest.GET("/menus/:menuId/foods", foodCtrl.GetFoodsByMenuID())
+		}
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func HealthRoutes(router *gin.Engine) {
+func HealthRoutes(router *gin.Engine, ctrl *controllers.HealthController) {
 	health := router.Group("/health")
 	{
+		health.GET("/live", ctrl.Live())
+		health.GET("/ready", ctrl.Ready())
 		health.GET("/router", controllers.GetRouterHealth)
 		health.GET("/database", controllers.GetDatabaseHealth)
 	}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/datarohit/go-restaurant-management-backend-project/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ImageRoutes(router *gin.Engine, imageCtrl *controllers.ImageController) {
+	api := router.Group("/api/v1")
+	{
+		images := api.Group("/images")
+		{
+			images.GET("/*key", imageCtrl.GetImage())
+		}
+	}
+}
@@ -6,15 +6,17 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func InvoiceRoutes(router *gin.Engine) {
+func InvoiceRoutes(router *gin.Engine, ctrl *controllers.InvoiceController) {
 	api := router.Group("/api/v1")
 	{
 		invoices := api.Group("/invoices")
 		{
-			invoices.POST("/", controllers.CreateInvoice())
-			invoices.GET("/", controllers.GetAllInvoices())
-			invoices.GET("/:invoiceId", controllers.GetInvoiceByID())
-			invoices.PATCH("/:invoiceId", controllers.UpdateInvoiceByID())
+			invoices.POST("/", ctrl.CreateInvoice())
+			invoices.GET("/", ctrl.GetAllInvoices())
+			invoices.GET("/:invoiceId", ctrl.GetInvoiceByID())
+			invoices.PATCH("/:invoiceId", ctrl.UpdateInvoiceByID())
+			invoices.DELETE("/:invoiceId", ctrl.DeleteInvoiceByID())
+			invoices.POST("/:invoiceId/restore", ctrl.RestoreInvoiceByID())
 		}
 	}
 }
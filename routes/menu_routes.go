@@ -6,15 +6,17 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func MenuRoutes(router *gin.Engine) {
+func MenuRoutes(router *gin.Engine, ctrl *controllers.MenuController) {
 	api := router.Group("/api/v1")
 	{
 		menus := api.Group("/menus")
 		{
-			menus.POST("/", controllers.CreateMenu())
-			menus.GET("/", controllers.GetAllMenus())
-			menus.GET("/:menuId", controllers.GetMenuByID())
-			menus.PATCH("/:menuId", controllers.UpdateMenuByID())
+			menus.POST("/", ctrl.CreateMenu())
+			menus.GET("/", ctrl.GetAllMenus())
+			menus.GET("/:menuId", ctrl.GetMenuByID())
+			menus.PATCH("/:menuId", ctrl.UpdateMenuByID())
+			menus.DELETE("/:menuId", ctrl.DeleteMenuByID())
+			menus.POST("/:menuId/restore", ctrl.RestoreMenuByID())
 		}
 	}
 }
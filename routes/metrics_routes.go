@@ -0,0 +1,10 @@
+package routes
+
+import (
+	"github.com/datarohit/go-restaurant-management-backend-project/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+func MetricsRoutes(router *gin.Engine) {
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+}
@@ -6,15 +6,18 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func OrderRoutes(router *gin.Engine) {
+func OrderRoutes(router *gin.Engine, ctrl *controllers.OrderController) {
 	api := router.Group("/api/v1")
 	{
 		orders := api.Group("/orders")
 		{
-			orders.POST("/", controllers.CreateOrder())
-			orders.GET("/", controllers.GetAllOrders())
-			orders.GET("/:orderId", controllers.GetOrderByID())
-			orders.PATCH("/:orderId", controllers.UpdateOrderByID())
+			orders.POST("/", ctrl.CreateOrder())
+			orders.GET("/", ctrl.GetAllOrders())
+			orders.GET("/:orderId", ctrl.GetOrderByID())
+			orders.GET("/:orderId/ticket", ctrl.GetOrderKitchenTicket())
+			orders.PATCH("/:orderId", ctrl.UpdateOrderByID())
+			orders.DELETE("/:orderId", ctrl.DeleteOrderByID())
+			orders.POST("/:orderId/restore", ctrl.RestoreOrderByID())
 		}
 	}
 }
@@ -6,16 +6,18 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func OrderItemRoutes(router *gin.Engine) {
+func OrderItemRoutes(router *gin.Engine, ctrl *controllers.OrderItemController) {
 	api := router.Group("/api/v1")
 	{
 		orderItems := api.Group("/orderItems")
 		{
-			orderItems.POST("/", controllers.CreateOrderItem())
-			orderItems.GET("/", controllers.GetAllOrderItems())
-			orderItems.GET("/order/:orderId", controllers.GetOrderItemsByOrderID())
-			orderItems.GET("/:orderItemId", controllers.GetOrderItemByID())
-			orderItems.PATCH("/:orderItemId", controllers.UpdateOrderItemByID())
+			orderItems.POST("/", ctrl.CreateOrderItem())
+			orderItems.GET("/", ctrl.GetAllOrderItems())
+			orderItems.GET("/order/:orderId", ctrl.GetOrderItemsByOrderID())
+			orderItems.GET("/:orderItemId", ctrl.GetOrderItemByID())
+			orderItems.PATCH("/:orderItemId", ctrl.UpdateOrderItemByID())
+			orderItems.DELETE("/:orderItemId", ctrl.DeleteOrderItemByID())
+			orderItems.POST("/:orderItemId/restore", ctrl.RestoreOrderItemByID())
 		}
 	}
 }
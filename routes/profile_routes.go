@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/datarohit/go-restaurant-management-backend-project/controllers"
+	"github.com/datarohit/go-restaurant-management-backend-project/middlewares"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ProfileRoutes(router *gin.Engine, ctrl *controllers.UserController) {
+	api := router.Group("/api/v1")
+	{
+		users := api.Group("/users")
+		{
+			users.GET("/me", ctrl.GetMe())
+			users.PATCH("/me", ctrl.UpdateMe())
+			users.PUT("/me/password", ctrl.ChangePassword())
+			users.POST("/:userId/deactivate", middlewares.RequireRole(models.UserRoleAdmin), ctrl.DeactivateUser())
+			users.POST("/:userId/reactivate", middlewares.RequireRole(models.UserRoleAdmin), ctrl.ReactivateUser())
+		}
+	}
+}
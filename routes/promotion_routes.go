@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/datarohit/go-restaurant-management-backend-project/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func PromotionRoutes(router *gin.Engine, ctrl *controllers.PromotionController) {
+	api := router.Group("/api/v1")
+	{
+		promotions := api.Group("/promotions")
+		{
+			promotions.POST("/", ctrl.CreatePromotion())
+			promotions.GET("/", ctrl.GetAllPromotions())
+			promotions.GET("/preview/:orderId", ctrl.PreviewOrderPromotions())
+			promotions.GET("/:promotionId", ctrl.GetPromotionByID())
+			promotions.PATCH("/:promotionId", ctrl.UpdatePromotionByID())
+			promotions.DELETE("/:promotionId", ctrl.DeletePromotionByID())
+			promotions.POST("/:promotionId/restore", ctrl.RestorePromotionByID())
+		}
+	}
+}
@@ -6,15 +6,17 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func TableRoutes(router *gin.Engine) {
+func TableRoutes(router *gin.Engine, ctrl *controllers.TableController) {
 	api := router.Group("/api/v1")
 	{
 		tables := api.Group("/tables")
 		{
-			tables.POST("/", controllers.CreateTable())
-			tables.GET("/", controllers.GetAllTables())
-			tables.GET("/:tableId", controllers.GetTableByID())
-			tables.PATCH("/:tableId", controllers.UpdateTableByID())
+			tables.POST("/", ctrl.CreateTable())
+			tables.GET("/", ctrl.GetAllTables())
+			tables.GET("/:tableId", ctrl.GetTableByID())
+			tables.PATCH("/:tableId", ctrl.UpdateTableByID())
+			tables.DELETE("/:tableId", ctrl.DeleteTableByID())
+			tables.POST("/:tableId/restore", ctrl.RestoreTableByID())
 		}
 	}
 }
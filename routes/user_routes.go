@@ -6,15 +6,20 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func UserRoutes(router *gin.Engine) {
+func UserRoutes(router *gin.Engine, ctrl *controllers.UserController, authLimits ...gin.HandlerFunc) {
 	api := router.Group("/api/v1")
 	{
 		users := api.Group("/users")
 		{
-			users.POST("/signup", controllers.SignUp())
-			users.POST("/login", controllers.Login())
-			users.GET("/:userId", controllers.GetUserByID())
-			users.GET("/", controllers.GetAllUsers())
+			auth := users.Group("", authLimits...)
+			auth.POST("/signup", ctrl.SignUp())
+			auth.POST("/login", ctrl.Login())
+			auth.POST("/forgot-password", ctrl.ForgotPassword())
+			auth.POST("/reset-password", ctrl.ResetPassword())
+			auth.POST("/verify-email", ctrl.VerifyEmail())
+			auth.POST("/resend-verification", ctrl.ResendVerification())
+			users.GET("/:userId", ctrl.GetUserByID())
+			users.GET("/", ctrl.GetAllUsers())
 		}
 	}
 }
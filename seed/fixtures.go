@@ -0,0 +1,56 @@
+package seed
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"gopkg.in/yaml.v3"
+)
+
+type MenuFixture struct {
+	models.Menu
+	Foods []models.Food `json:"foods"`
+}
+
+type UserFixture struct {
+	models.SignUpRequest
+	Role *string `json:"role"`
+}
+
+type Fixtures struct {
+	Menus  []MenuFixture  `json:"menus"`
+	Tables []models.Table `json:"tables"`
+	Users  []UserFixture  `json:"users"`
+}
+
+func LoadFixtures(path string) (*Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading fixtures: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var document interface{}
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("parsing fixtures: %w", err)
+		}
+		if data, err = json.Marshal(document); err != nil {
+			return nil, fmt.Errorf("converting fixtures: %w", err)
+		}
+	case ".json":
+	default:
+		return nil, fmt.Errorf("unsupported fixture format %q (expected .yaml, .yml or .json)", filepath.Ext(path))
+	}
+
+	var fixtures Fixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("decoding fixtures: %w", err)
+	}
+
+	return &fixtures, nil
+}
@@ -0,0 +1,171 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrNothingToOrder = errors.New("no foods or tables found; run the seed fixtures first")
+
+var (
+	orderQuantities = []string{"S", "M", "L"}
+	paymentMethods  = []string{"CARD", "CASH", "ONLINE"}
+	serviceHours    = []int{12, 12, 13, 13, 14, 18, 19, 19, 20, 20, 21}
+)
+
+type GenerateOptions struct {
+	Months           int
+	OrdersPerDay     int
+	MaxItemsPerOrder int
+	Seed             int64
+	End              time.Time
+}
+
+type GenerateSummary struct {
+	Orders     int `json:"orders"`
+	OrderItems int `json:"orderItems"`
+	Invoices   int `json:"invoices"`
+}
+
+type Generator struct {
+	repos *repositories.Repositories
+}
+
+func NewGenerator(repos *repositories.Repositories) *Generator {
+	return &Generator{repos: repos}
+}
+
+func (g *Generator) Generate(ctx context.Context, opts GenerateOptions) (GenerateSummary, error) {
+	var summary GenerateSummary
+
+	if opts.Months <= 0 || opts.OrdersPerDay <= 0 || opts.MaxItemsPerOrder <= 0 {
+		return summary, fmt.Errorf("months, orders per day and max items per order must be positive")
+	}
+	if opts.End.IsZero() {
+		opts.End = time.Now().UTC()
+	}
+
+	foods, _, err := g.repos.Foods.List(ctx, repositories.FoodFilter{}, repositories.Query{})
+	if err != nil {
+		return summary, fmt.Errorf("loading foods: %w", err)
+	}
+	tables, _, err := g.repos.Tables.List(ctx, repositories.Query{})
+	if err != nil {
+		return summary, fmt.Errorf("loading tables: %w", err)
+	}
+
+	priced := foods[:0]
+	for _, food := range foods {
+		if food.Price != nil {
+			priced = append(priced, food)
+		}
+	}
+	if len(priced) == 0 || len(tables) == 0 {
+		return summary, ErrNothingToOrder
+	}
+
+	random := rand.New(rand.NewSource(opts.Seed))
+	end := opts.End.Truncate(24 * time.Hour)
+	start := end.AddDate(0, -opts.Months, 0)
+
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		dayOrders := opts.OrdersPerDay/2 + random.Intn(opts.OrdersPerDay+1)
+		if day.Weekday() == time.Friday || day.Weekday() == time.Saturday {
+			dayOrders = dayOrders * 3 / 2
+		}
+
+		var (
+			orders     []models.Order
+			orderItems []models.OrderItem
+			invoices   []models.Invoice
+		)
+
+		for i := 0; i < dayOrders; i++ {
+			orderDate := day.Add(time.Duration(serviceHours[random.Intn(len(serviceHours))])*time.Hour +
+				time.Duration(random.Intn(60))*time.Minute)
+			table := tables[random.Intn(len(tables))]
+
+			order := models.Order{
+				ID:        primitive.NewObjectID(),
+				OrderDate: orderDate,
+				CreatedAt: orderDate,
+				UpdatedAt: orderDate,
+				TableID:   &table.TableID,
+			}
+			order.OrderID = order.ID.Hex()
+			orders = append(orders, order)
+
+			subtotal := 0.0
+			for j := 1 + random.Intn(opts.MaxItemsPerOrder); j > 0; j-- {
+				food := priced[random.Intn(len(priced))]
+				quantity := orderQuantities[random.Intn(len(orderQuantities))]
+				unitPrice := *food.Price
+				foodID := food.FoodID
+
+				orderItem := models.OrderItem{
+					ID:        primitive.NewObjectID(),
+					Quantity:  &quantity,
+					UnitPrice: &unitPrice,
+					CreatedAt: orderDate,
+					UpdatedAt: orderDate,
+					FoodID:    &foodID,
+					Allergens: food.Allergens,
+					OrderID:   order.OrderID,
+				}
+				orderItem.OrderItemID = orderItem.ID.Hex()
+				orderItems = append(orderItems, orderItem)
+				subtotal += unitPrice
+			}
+
+			paymentMethod := paymentMethods[random.Intn(len(paymentMethods))]
+			paymentStatus := "PAID"
+			if orderDate.After(opts.End.Add(-24*time.Hour)) && random.Intn(2) == 0 {
+				paymentStatus = "PENDING"
+			}
+			subtotal = helper.ToFixed(subtotal, 2)
+
+			invoice := models.Invoice{
+				ID:                primitive.NewObjectID(),
+				OrderID:           order.OrderID,
+				PaymentMethod:     &paymentMethod,
+				PaymentStatus:     &paymentStatus,
+				PaymentDueDate:    orderDate.Add(24 * time.Hour),
+				Subtotal:          subtotal,
+				Total:             subtotal,
+				AppliedPromotions: []models.AppliedPromotion{},
+				CreatedAt:         orderDate,
+				UpdatedAt:         orderDate,
+			}
+			invoice.InvoiceID = invoice.ID.Hex()
+			invoices = append(invoices, invoice)
+		}
+
+		for i := range orders {
+			if err := g.repos.Orders.Create(ctx, &orders[i]); err != nil {
+				return summary, fmt.Errorf("inserting orders: %w", err)
+			}
+		}
+		if err := g.repos.OrderItems.CreateMany(ctx, orderItems); err != nil {
+			return summary, fmt.Errorf("inserting order items: %w", err)
+		}
+		for i := range invoices {
+			if err := g.repos.Invoices.Create(ctx, &invoices[i]); err != nil {
+				return summary, fmt.Errorf("inserting invoices: %w", err)
+			}
+		}
+
+		summary.Orders += len(orders)
+		summary.OrderItems += len(orderItems)
+		summary.Invoices += len(invoices)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,228 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	helper "github.com/datarohit/go-restaurant-management-backend-project/helpers"
+	"github.com/datarohit/go-restaurant-management-backend-project/models"
+	"github.com/datarohit/go-restaurant-management-backend-project/repositories"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Count struct {
+	Created int `json:"created"`
+	Skipped int `json:"skipped"`
+}
+
+type Summary struct {
+	Menus  Count `json:"menus"`
+	Foods  Count `json:"foods"`
+	Tables Count `json:"tables"`
+	Users  Count `json:"users"`
+}
+
+type Seeder struct {
+	repos *repositories.Repositories
+}
+
+func NewSeeder(repos *repositories.Repositories) *Seeder {
+	return &Seeder{repos: repos}
+}
+
+func (s *Seeder) Seed(ctx context.Context, fixtures *Fixtures) (Summary, error) {
+	var summary Summary
+	ctx = repositories.WithDeleted(ctx)
+
+	for _, menuFixture := range fixtures.Menus {
+		menuID, created, err := s.seedMenu(ctx, menuFixture.Menu)
+		if err != nil {
+			return summary, err
+		}
+		summary.Menus.add(created)
+
+		for _, food := range menuFixture.Foods {
+			created, err := s.seedFood(ctx, menuID, food)
+			if err != nil {
+				return summary, err
+			}
+			summary.Foods.add(created)
+		}
+	}
+
+	for _, table := range fixtures.Tables {
+		created, err := s.seedTable(ctx, table)
+		if err != nil {
+			return summary, err
+		}
+		summary.Tables.add(created)
+	}
+
+	for _, user := range fixtures.Users {
+		created, err := s.seedUser(ctx, user)
+		if err != nil {
+			return summary, err
+		}
+		summary.Users.add(created)
+	}
+
+	return summary, nil
+}
+
+func (c *Count) add(created bool) {
+	if created {
+		c.Created++
+	} else {
+		c.Skipped++
+	}
+}
+
+func (s *Seeder) seedMenu(ctx context.Context, menu models.Menu) (string, bool, error) {
+	if menu.Name == "" || menu.Category == "" {
+		return "", false, fmt.Errorf("menu fixtures require a name and category")
+	}
+
+	existing, _, err := s.repos.Menus.List(ctx, lookup("name", menu.Name))
+	if err != nil {
+		return "", false, fmt.Errorf("looking up menu %q: %w", menu.Name, err)
+	} else if len(existing) > 0 {
+		return existing[0].MenuID, false, nil
+	}
+
+	now := time.Now().UTC()
+	menu.ID = primitive.NewObjectID()
+	menu.MenuID = menu.ID.Hex()
+	menu.CreatedAt = now
+	menu.UpdatedAt = now
+
+	if err := s.repos.Menus.Create(ctx, &menu); err != nil {
+		return "", false, fmt.Errorf("creating menu %q: %w", menu.Name, err)
+	}
+	return menu.MenuID, true, nil
+}
+
+func (s *Seeder) seedFood(ctx context.Context, menuID string, food models.Food) (bool, error) {
+	if food.Name == nil || food.Price == nil {
+		return false, fmt.Errorf("food fixtures require a name and price")
+	}
+
+	existing, _, err := s.repos.Foods.List(ctx, repositories.FoodFilter{MenuID: menuID}, lookup("name", *food.Name))
+	if err != nil {
+		return false, fmt.Errorf("looking up food %q: %w", *food.Name, err)
+	} else if len(existing) > 0 {
+		return false, nil
+	}
+
+	now := time.Now().UTC()
+	price := helper.ToFixed(*food.Price, 2)
+	food.Price = &price
+	food.MenuID = &menuID
+	food.Allergens = helper.NormalizeFoodLabels(food.Allergens)
+	food.DietaryFlags = helper.NormalizeFoodLabels(food.DietaryFlags)
+	food.ID = primitive.NewObjectID()
+	food.FoodID = food.ID.Hex()
+	food.CreatedAt = now
+	food.UpdatedAt = now
+
+	for _, allergen := range food.Allergens {
+		if !models.IsValidAllergen(allergen) {
+			return false, fmt.Errorf("food %q has unknown allergen %q", *food.Name, allergen)
+		}
+	}
+	for _, flag := range food.DietaryFlags {
+		if !models.IsValidDietaryFlag(flag) {
+			return false, fmt.Errorf("food %q has unknown dietary flag %q", *food.Name, flag)
+		}
+	}
+
+	if err := s.repos.Foods.Create(ctx, &food); err != nil {
+		return false, fmt.Errorf("creating food %q: %w", *food.Name, err)
+	}
+
+	if _, err := helper.RecordFoodPrice(ctx, s.repos.FoodPrices, food.FoodID, price, now); err != nil {
+		return false, fmt.Errorf("recording price for food %q: %w", *food.Name, err)
+	}
+	return true, nil
+}
+
+func (s *Seeder) seedTable(ctx context.Context, table models.Table) (bool, error) {
+	if table.TableNumber == nil || table.NumberOfGuests == nil {
+		return false, fmt.Errorf("table fixtures require a tableNumber and numberOfGuests")
+	}
+
+	existing, _, err := s.repos.Tables.List(ctx, lookup("tableNumber", *table.TableNumber))
+	if err != nil {
+		return false, fmt.Errorf("looking up table %d: %w", *table.TableNumber, err)
+	} else if len(existing) > 0 {
+		return false, nil
+	}
+
+	now := time.Now().UTC()
+	table.ID = primitive.NewObjectID()
+	table.TableID = table.ID.Hex()
+	table.CreatedAt = now
+	table.UpdatedAt = now
+
+	if err := s.repos.Tables.Create(ctx, &table); err != nil {
+		return false, fmt.Errorf("creating table %d: %w", *table.TableNumber, err)
+	}
+	return true, nil
+}
+
+func (s *Seeder) seedUser(ctx context.Context, fixture UserFixture) (bool, error) {
+	if fixture.Email == "" || fixture.Password == "" || fixture.Phone == "" {
+		return false, fmt.Errorf("user fixtures require an email, password and phone")
+	}
+
+	user := models.User{
+		FirstName: &fixture.FirstName,
+		LastName:  &fixture.LastName,
+		Password:  &fixture.Password,
+		Email:     &fixture.Email,
+		Avatar:    fixture.Avatar,
+		Phone:     &fixture.Phone,
+		Role:      fixture.Role,
+	}
+
+	_, err := s.repos.Users.FindByEmail(ctx, *user.Email)
+	if err == nil {
+		return false, nil
+	} else if !errors.Is(err, repositories.ErrNotFound) {
+		return false, fmt.Errorf("looking up user %q: %w", *user.Email, err)
+	}
+
+	if user.Role == nil {
+		role := models.UserRoleStaff
+		user.Role = &role
+	}
+	switch *user.Role {
+	case models.UserRoleAdmin, models.UserRoleManager, models.UserRoleStaff:
+	default:
+		return false, fmt.Errorf("user %q has unknown role %q", *user.Email, *user.Role)
+	}
+
+	hashedPassword, err := helper.HashPassword(user.Password)
+	if err != nil {
+		return false, fmt.Errorf("hashing password for user %q: %w", *user.Email, err)
+	}
+
+	now := time.Now().UTC()
+	user.Password = hashedPassword
+	user.ID = primitive.NewObjectID()
+	user.UserID = user.ID.Hex()
+	user.EmailVerified = true
+	user.EmailVerifiedAt = &now
+	user.CreatedAt = now
+	user.UpdatedAt = now
+
+	if err := s.repos.Users.Create(ctx, &user); err != nil {
+		return false, fmt.Errorf("creating user %q: %w", *user.Email, err)
+	}
+	return true, nil
+}
+
+func lookup(field string, value interface{}) repositories.Query {
+	return repositories.Query{Limit: 1}.Where(field, repositories.OpEq, value)
+}
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/datarohit/go-restaurant-management-backend-project/config"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+var ErrInvalidKey = errors.New("invalid blob key")
+
+type BlobInfo struct {
+	Key         string
+	ContentType string
+	Size        int64
+}
+
+type BlobStore interface {
+	Save(ctx context.Context, key, contentType string, data io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, BlobInfo, error)
+	Delete(ctx context.Context, key string) error
+}
+
+func NewBlobStore(client *mongo.Client, databaseName string, cfg config.StorageConfig) BlobStore {
+	switch cfg.Driver {
+	case "gridfs":
+		return NewGridFSBlobStore(client, databaseName, cfg.GridFSBucket)
+	default:
+		return NewLocalBlobStore(cfg.LocalPath)
+	}
+}
@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type GridFSBlobStore struct {
+	client       *mongo.Client
+	databaseName string
+	bucketName   string
+}
+
+func NewGridFSBlobStore(client *mongo.Client, databaseName, bucketName string) *GridFSBlobStore {
+	return &GridFSBlobStore{client: client, databaseName: databaseName, bucketName: bucketName}
+}
+
+func (s *GridFSBlobStore) Save(ctx context.Context, key, contentType string, data io.Reader) error {
+	bucket, err := s.bucket(ctx)
+	if err != nil {
+		return err
+	}
+
+	previous, err := s.findFiles(ctx, bucket, key)
+	if err != nil {
+		return err
+	}
+
+	opts := options.GridFSUpload().SetMetadata(bson.M{"contentType": contentType})
+	if _, err := bucket.UploadFromStream(key, data, opts); err != nil {
+		return err
+	}
+
+	for _, file := range previous {
+		if err := bucket.Delete(file.ID); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *GridFSBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, BlobInfo, error) {
+	bucket, err := s.bucket(ctx)
+	if err != nil {
+		return nil, BlobInfo{}, err
+	}
+
+	files, err := s.findFiles(ctx, bucket, key)
+	if err != nil {
+		return nil, BlobInfo{}, err
+	}
+	if len(files) == 0 {
+		return nil, BlobInfo{}, ErrBlobNotFound
+	}
+	file := files[len(files)-1]
+
+	stream, err := bucket.OpenDownloadStream(file.ID)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return nil, BlobInfo{}, ErrBlobNotFound
+	} else if err != nil {
+		return nil, BlobInfo{}, err
+	}
+
+	var metadata struct {
+		ContentType string `bson:"contentType"`
+	}
+	if file.Metadata != nil {
+		_ = bson.Unmarshal(file.Metadata, &metadata)
+	}
+	if metadata.ContentType == "" {
+		metadata.ContentType = "application/octet-stream"
+	}
+
+	return stream, BlobInfo{Key: key, ContentType: metadata.ContentType, Size: file.Length}, nil
+}
+
+func (s *GridFSBlobStore) Delete(ctx context.Context, key string) error {
+	bucket, err := s.bucket(ctx)
+	if err != nil {
+		return err
+	}
+
+	files, err := s.findFiles(ctx, bucket, key)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return ErrBlobNotFound
+	}
+
+	for _, file := range files {
+		if err := bucket.Delete(file.ID); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *GridFSBlobStore) bucket(ctx context.Context) (*gridfs.Bucket, error) {
+	if s.client == nil {
+		return nil, errors.New("gridfs blob store has no database client")
+	}
+
+	bucket, err := gridfs.NewBucket(s.client.Database(s.databaseName), options.GridFSBucket().SetName(s.bucketName))
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := bucket.SetReadDeadline(deadline); err != nil {
+			return nil, err
+		}
+		if err := bucket.SetWriteDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	return bucket, nil
+}
+
+func (s *GridFSBlobStore) findFiles(ctx context.Context, bucket *gridfs.Bucket, key string) ([]gridfs.File, error) {
+	opts := options.GridFSFind().SetSort(bson.D{{Key: "uploadDate", Value: 1}})
+	cursor, err := bucket.Find(bson.M{"filename": key}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var files []gridfs.File
+	if err := cursor.All(ctx, &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalBlobStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store := NewLocalBlobStore(root)
+
+	if err := store.Save(ctx, "foods/abc/original.png", "image/png", strings.NewReader("png-bytes")); err != nil {
+		t.Fatalf("saving: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "foods", "abc", "original.png")); err != nil {
+		t.Fatalf("expected the blob under the root: %v", err)
+	}
+
+	reader, info, err := store.Open(ctx, "foods/abc/original.png")
+	if err != nil {
+		t.Fatalf("opening: %v", err)
+	}
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(data) != "png-bytes" || info.Size != 9 || info.ContentType != "image/png" {
+		t.Fatalf("unexpected blob %q with %+v", data, info)
+	}
+
+	if err := store.Delete(ctx, "foods/abc/original.png"); err != nil {
+		t.Fatalf("deleting: %v", err)
+	}
+	if _, _, err := store.Open(ctx, "foods/abc/original.png"); !errors.Is(err, ErrBlobNotFound) {
+		t.Fatalf("expected ErrBlobNotFound after deleting, got %v", err)
+	}
+	if err := store.Delete(ctx, "foods/abc/original.png"); !errors.Is(err, ErrBlobNotFound) {
+		t.Fatalf("expected ErrBlobNotFound for a second delete, got %v", err)
+	}
+}
+
+func TestLocalBlobStoreRejectsInvalidKeys(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalBlobStore(t.TempDir())
+
+	for _, key := range []string{"", "/", "../secret", "foods/../../secret", "foods/.."} {
+		if _, _, err := store.Open(ctx, key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Open(%q): expected ErrInvalidKey, got %v", key, err)
+		}
+		if err := store.Save(ctx, key, "image/png", strings.NewReader("x")); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Save(%q): expected ErrInvalidKey, got %v", key, err)
+		}
+		if err := store.Delete(ctx, key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Delete(%q): expected ErrInvalidKey, got %v", key, err)
+		}
+	}
+}
@@ -1,48 +1,117 @@
 package utils
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"os"
 	"time"
 
+	"github.com/datarohit/go-restaurant-management-backend-project/config"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-var logger *zap.Logger
+var (
+	logger *zap.Logger
+	level  = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+)
+
+type loggerKey struct{}
+
+func InitializeLogger(cfg config.LoggingConfig) error {
+	logLevel, err := zapcore.ParseLevel(cfg.Level)
+	if err != nil {
+		return fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+	}
 
-func InitializeLogger(logLevel zapcore.Level, outputPaths []string) error {
-	config := zap.NewProductionConfig()
-	config.Level.SetLevel(logLevel)
-	config.OutputPaths = outputPaths
+	var encoder zapcore.Encoder
+	switch cfg.Format {
+	case "json":
+		encoder = zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	case "console":
+		encoder = zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	default:
+		return fmt.Errorf("invalid log format %q (expected json or console)", cfg.Format)
+	}
 
-	var err error
-	logger, err = config.Build()
-	return err
+	if len(cfg.Outputs) == 0 {
+		cfg.Outputs = []string{"stdout"}
+	}
+	writers := make([]zapcore.WriteSyncer, 0, len(cfg.Outputs))
+	for _, path := range cfg.Outputs {
+		switch path {
+		case "stdout":
+			writers = append(writers, zapcore.Lock(os.Stdout))
+		case "stderr":
+			writers = append(writers, zapcore.Lock(os.Stderr))
+		default:
+			writers = append(writers, zapcore.AddSync(&lumberjack.Logger{
+				Filename:   path,
+				MaxSize:    cfg.FileMaxSizeMB,
+				MaxAge:     cfg.FileMaxAgeDays,
+				MaxBackups: cfg.FileMaxBackups,
+				Compress:   cfg.FileCompress,
+			}))
+		}
+	}
+
+	level.SetLevel(logLevel)
+	core := zapcore.NewCore(encoder, zapcore.NewMultiWriteSyncer(writers...), level)
+	if cfg.Sampling {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, cfg.SamplingInitial, cfg.SamplingThereafter)
+	}
+
+	logger = zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
+	return nil
 }
 
 func GetLogger() *zap.Logger {
 	if logger == nil {
-		_ = InitializeLogger(zapcore.InfoLevel, []string{"stdout"})
+		_ = InitializeLogger(config.LoggingConfig{Level: "info", Format: "json"})
 	}
 	return logger
 }
 
-func LogRequest(r *http.Request, traceID string) {
+func LogLevel() zap.AtomicLevel {
+	return level
+}
+
+func SyncLogger() error {
+	if logger == nil {
+		return nil
+	}
+	return logger.Sync()
+}
+
+func WithLogger(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+func LoggerFrom(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return GetLogger()
+}
+
+func LogRequest(r *http.Request, requestID string) {
 	logger.Info("Incoming request",
 		zap.String("method", r.Method),
 		zap.String("url", r.URL.Path),
 		zap.String("remote_addr", r.RemoteAddr),
-		zap.String("trace_id", traceID),
+		zap.String("requestId", requestID),
 		zap.Time("timestamp", time.Now().UTC()),
 	)
 }
 
-func LogError(r *http.Request, traceID, msg string, err error) {
+func LogError(r *http.Request, requestID, msg string, err error) {
 	logger.Error("Request error",
 		zap.String("method", r.Method),
 		zap.String("url", r.URL.Path),
 		zap.String("remote_addr", r.RemoteAddr),
-		zap.String("trace_id", traceID),
+		zap.String("requestId", requestID),
 		zap.String("message", msg),
 		zap.Error(err),
 		zap.Time("timestamp", time.Now().UTC()),